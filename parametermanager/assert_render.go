@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_assert_render]
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// assertRenderEquals renders a parameter version and checks that the result is exactly
+// expected, which makes it usable as a deployment gate. The payloads are compared byte
+// for byte; the diff in the error only shows the line numbers and keys that differ, so
+// that no value of the rendered payload, which may be a resolved secret, is logged.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+// expected: The payload the rendered version must match.
+//
+// The function returns an error with a redacted diff of the expected and rendered
+// payloads if they differ, or an error if the parameter version rendering fails.
+func assertRenderEquals(ctx context.Context, projectID, parameterID, versionID string, expected []byte) error {
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return assertRenderEqualsWithClient(ctx, client, projectID, parameterID, versionID, expected)
+}
+
+// assertRenderEqualsWithClient renders the version through client and compares the
+// rendered payload with expected.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+// expected: The payload the rendered version must match.
+//
+// The function returns an error with a redacted diff if the payloads differ, or an
+// error if the parameter version rendering fails.
+func assertRenderEqualsWithClient(ctx context.Context, client ParameterClient, projectID, parameterID, versionID string, expected []byte) error {
+	// Construct the name of the parameter version to render.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+
+	// Call the API to render the parameter version.
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render parameter version: %w", err)
+	}
+	if bytes.Equal(rendered.RenderedPayload, expected) {
+		return nil
+	}
+
+	// Show where the payloads differ as a unified diff, reusing diffLines from the
+	// diffParamVersions sample. The rendered payload can hold resolved secrets and the
+	// error is meant for CI logs, so each line is reduced to its key and the value is
+	// replaced with "***"; the hunk headers give the line numbers.
+	var diff strings.Builder
+	for _, line := range diffLines(strings.Split(string(expected), "\n"), strings.Split(string(rendered.RenderedPayload), "\n")) {
+		if !strings.HasPrefix(line, "@@") {
+			if i := strings.IndexAny(line[1:], "=:"); i >= 0 {
+				line = line[:i+2] + "***"
+			} else {
+				line = line[:1] + "***"
+			}
+		}
+		fmt.Fprintf(&diff, "\n%s", line)
+	}
+	return fmt.Errorf("rendered payload of %s does not match expected, values redacted:\n--- expected\n+++ rendered%s", name, diff.String())
+}
+
+// [END parametermanager_assert_render]
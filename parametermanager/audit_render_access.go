@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_audit_render_access]
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+)
+
+// auditRenderAccess reports, for each secret referenced by a parameter version, whether the
+// calling identity holds secretmanager.versions.access on the secret, using the
+// Parameter Manager and Secret Manager SDKs for GCP.
+//
+// TestIamPermissions checks the caller's own permissions. When Parameter Manager renders
+// a version it reads the secrets as the parameter's service identity
+// (parameter.PolicyMember), so run this as that identity to audit a real render.
+// References to regional secrets are reported as not checked, since they need a
+// Secret Manager client for the secret's location.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter holding the version.
+// versionID: The ID of the version to be audited.
+//
+// The function returns an error if the parameter version retrieval fails.
+func auditRenderAccess(w io.Writer, projectID, parameterID, versionID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	pmClient, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer pmClient.Close()
+
+	// Get the stored payload, which leaves the secret references unresolved.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+	version, err := pmClient.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter version: %w", err)
+	}
+
+	// Find the secret versions referenced by the payload. The quotes around a reference
+	// are optional and are escaped when the reference sits inside a JSON string.
+	refRE := regexp.MustCompile(`__REF__\(\s*\\?"?//secretmanager\.googleapis\.com/([^"\\)\s]+)\\?"?\s*\)`)
+	var refs []string
+	seen := make(map[string]bool)
+	for _, match := range refRE.FindAllSubmatch(version.GetPayload().GetData(), -1) {
+		if ref := string(match[1]); !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	// Create a Secret Manager client.
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Secret Manager client: %w", err)
+	}
+	defer client.Close()
+
+	const permission = "secretmanager.versions.access"
+	accessible := 0
+	for _, ref := range refs {
+		// The permission is granted on the secret, not on individual versions.
+		secret := ref
+		if i := strings.Index(ref, "/versions/"); i >= 0 {
+			secret = ref[:i]
+		}
+		if strings.Contains(secret, "/locations/") {
+			fmt.Fprintf(w, "Not checked (regional secret): %s\n", ref)
+			continue
+		}
+
+		granted, err := client.IAM(secret).TestPermissions(ctx, []string{permission})
+		switch {
+		case err != nil:
+			fmt.Fprintf(w, "Not accessible: %s (%v)\n", ref, err)
+		case len(granted) == 0:
+			fmt.Fprintf(w, "Not accessible: %s (missing %s)\n", ref, permission)
+		default:
+			fmt.Fprintf(w, "Accessible: %s\n", ref)
+			accessible++
+		}
+	}
+
+	fmt.Fprintf(w, "%d of %d secret references are accessible\n", accessible, len(refs))
+	return nil
+}
+
+// [END parametermanager_audit_render_access]
@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_batch_get_param_versions]
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"golang.org/x/sync/errgroup"
+)
+
+// batchGetVersions gets many versions of a parameter concurrently using the Parameter Manager SDK for GCP.
+//
+// At most 10 versions are fetched at a time. A failed fetch does not cancel the others,
+// so the returned map holds every version that was fetched even when an error is returned.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose versions are to be fetched.
+// versionIDs: The IDs of the versions to be fetched.
+//
+// The function returns the fetched versions keyed by version ID, and the first error
+// encountered, if any.
+func batchGetVersions(ctx context.Context, projectID, parameterID string, versionIDs []string) (map[string]*parametermanagerpb.ParameterVersion, error) {
+	// Create a Parameter Manager client shared by all the fetches.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parent parameter for the versions.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Fetch the versions with bounded concurrency.
+	var g errgroup.Group
+	g.SetLimit(10)
+
+	var mu sync.Mutex
+	versions := make(map[string]*parametermanagerpb.ParameterVersion, len(versionIDs))
+	for _, versionID := range versionIDs {
+		g.Go(func() error {
+			version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+				Name: fmt.Sprintf("%s/versions/%s", parent, versionID),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get parameter version %s: %w", versionID, err)
+			}
+			mu.Lock()
+			versions[versionID] = version
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	return versions, err
+}
+
+// [END parametermanager_batch_get_param_versions]
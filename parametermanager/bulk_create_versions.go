@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_bulk_create_param_versions]
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"golang.org/x/sync/errgroup"
+)
+
+// bulkCreateVersions creates many parameter versions concurrently using the Parameter Manager SDK for GCP.
+//
+// At most 10 versions are created at a time. The first failure cancels the
+// creations that have not finished yet.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the versions are to be created.
+// versions: The payloads of the versions to be created, keyed by version ID.
+//
+// The function returns how many of the versions were created successfully, and the
+// first creation error, if any.
+func bulkCreateVersions(ctx context.Context, projectID, parameterID string, versions map[string][]byte) (int, error) {
+	// Create a Parameter Manager client shared by all the creations.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parent parameter for the versions.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Create the versions with bounded concurrency.
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(10)
+
+	var created atomic.Int64
+	for versionID, payload := range versions {
+		g.Go(func() error {
+			req := &parametermanagerpb.CreateParameterVersionRequest{
+				Parent:             parent,
+				ParameterVersionId: versionID,
+				ParameterVersion: &parametermanagerpb.ParameterVersion{
+					Payload: &parametermanagerpb.ParameterVersionPayload{
+						Data: payload,
+					},
+				},
+			}
+			if _, err := client.CreateParameterVersion(gctx, req); err != nil {
+				return fmt.Errorf("failed to create parameter version %s: %w", versionID, err)
+			}
+			created.Add(1)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return int(created.Load()), fmt.Errorf("created %d of %d parameter versions: %w", created.Load(), len(versions), err)
+	}
+	return int(created.Load()), nil
+}
+
+// [END parametermanager_bulk_create_param_versions]
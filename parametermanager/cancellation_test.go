@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// cancelWriter is an io.Writer that cancels a context after its first write.
+type cancelWriter struct {
+	bytes.Buffer
+	cancel context.CancelFunc
+}
+
+func (w *cancelWriter) Write(p []byte) (int, error) {
+	defer w.cancel()
+	return w.Buffer.Write(p)
+}
+
+// TestListParamsCancellation tests that listParamsWithClient stops with context.Canceled
+// when the context is cancelled part way through the first page, instead of draining
+// the remaining parameters and pages.
+func TestListParamsCancellation(t *testing.T) {
+	srv := newFakeParameterManagerServer("project", 3*fakeServerDefaultPageSize)
+	client := newFakeServerClient(t, srv)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := &cancelWriter{cancel: cancel}
+
+	err := listParamsWithClient(ctx, client, w, "project")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("listParams: expected context.Canceled, got %v", err)
+	}
+	if got := strings.Count(w.String(), "Found parameter "); got != 1 {
+		t.Errorf("listParams: expected 1 parameter before the cancellation, got %d", got)
+	}
+	if got := srv.calls(); got != 1 {
+		t.Errorf("listParams: expected 1 ListParameters call, got %d", got)
+	}
+}
+
+// TestCountParamsCancellation tests that countParamsWithClient returns context.Canceled
+// for a cancelled context without listing any parameters.
+func TestCountParamsCancellation(t *testing.T) {
+	srv := newFakeParameterManagerServer("project", 3*fakeServerDefaultPageSize)
+	client := newFakeServerClient(t, srv)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	count, err := countParamsWithClient(ctx, client, "project")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("countParams: expected context.Canceled, got %v", err)
+	}
+	if count != 0 {
+		t.Errorf("countParams: expected a count of 0, got %d", count)
+	}
+	if got := srv.calls(); got != 0 {
+		t.Errorf("countParams: expected no ListParameters calls, got %d", got)
+	}
+}
+
+// TestCountParamsWithFakeServer tests that countParamsWithClient counts every parameter
+// across several pages, and none of another project's.
+func TestCountParamsWithFakeServer(t *testing.T) {
+	srv := newFakeParameterManagerServer("project", 2500)
+	srv.parameters = append(srv.parameters, newFakeParameterManagerServer("other-project", 10).parameters...)
+	client := newFakeServerClient(t, srv)
+
+	count, err := countParamsWithClient(context.Background(), client, "project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2500 {
+		t.Errorf("countParams: expected a count of 2500, got %d", count)
+	}
+	if got := srv.calls(); got != 3 {
+		t.Errorf("countParams: expected 3 ListParameters calls, got %d", got)
+	}
+}
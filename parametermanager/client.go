@@ -0,0 +1,47 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_parameter_client]
+import (
+	"context"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"github.com/googleapis/gax-go/v2"
+)
+
+// ParameterClient is the subset of the Parameter Manager client used by the samples.
+// Samples that accept a client take a ParameterClient rather than a *parametermanager.Client,
+// which satisfies it, so tests can substitute a fake to run them without a project or
+// credentials.
+type ParameterClient interface {
+	CreateParameter(ctx context.Context, req *parametermanagerpb.CreateParameterRequest, opts ...gax.CallOption) (*parametermanagerpb.Parameter, error)
+	GetParameter(ctx context.Context, req *parametermanagerpb.GetParameterRequest, opts ...gax.CallOption) (*parametermanagerpb.Parameter, error)
+	ListParameters(ctx context.Context, req *parametermanagerpb.ListParametersRequest, opts ...gax.CallOption) *parametermanager.ParameterIterator
+	UpdateParameter(ctx context.Context, req *parametermanagerpb.UpdateParameterRequest, opts ...gax.CallOption) (*parametermanagerpb.Parameter, error)
+	DeleteParameter(ctx context.Context, req *parametermanagerpb.DeleteParameterRequest, opts ...gax.CallOption) error
+
+	CreateParameterVersion(ctx context.Context, req *parametermanagerpb.CreateParameterVersionRequest, opts ...gax.CallOption) (*parametermanagerpb.ParameterVersion, error)
+	GetParameterVersion(ctx context.Context, req *parametermanagerpb.GetParameterVersionRequest, opts ...gax.CallOption) (*parametermanagerpb.ParameterVersion, error)
+	ListParameterVersions(ctx context.Context, req *parametermanagerpb.ListParameterVersionsRequest, opts ...gax.CallOption) *parametermanager.ParameterVersionIterator
+	RenderParameterVersion(ctx context.Context, req *parametermanagerpb.RenderParameterVersionRequest, opts ...gax.CallOption) (*parametermanagerpb.RenderParameterVersionResponse, error)
+	UpdateParameterVersion(ctx context.Context, req *parametermanagerpb.UpdateParameterVersionRequest, opts ...gax.CallOption) (*parametermanagerpb.ParameterVersion, error)
+	DeleteParameterVersion(ctx context.Context, req *parametermanagerpb.DeleteParameterVersionRequest, opts ...gax.CallOption) error
+}
+
+var _ ParameterClient = (*parametermanager.Client)(nil)
+
+// [END parametermanager_parameter_client]
@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_client_pool]
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/option"
+)
+
+// ClientPool creates one Parameter Manager client per endpoint on first use and hands
+// out the same client afterwards, so callers that make many requests do not pay for a
+// new connection on each one.
+//
+// The global location uses the default endpoint; every other location uses its
+// regional endpoint. A ClientPool is safe for concurrent use.
+type ClientPool struct {
+	// newClient creates a client; tests replace it to count creations.
+	newClient func(ctx context.Context, opts ...option.ClientOption) (*parametermanager.Client, error)
+
+	mu      sync.Mutex
+	clients map[string]*parametermanager.Client
+}
+
+// NewClientPool returns an empty ClientPool.
+func NewClientPool() *ClientPool {
+	return &ClientPool{
+		newClient: parametermanager.NewClient,
+		clients:   make(map[string]*parametermanager.Client),
+	}
+}
+
+// Client returns the client for locationID, creating it if this is the first request
+// for that location. An empty locationID means the global location.
+//
+// The returned client belongs to the pool: callers must not close it.
+func (p *ClientPool) Client(ctx context.Context, locationID string) (*parametermanager.Client, error) {
+	if locationID == "" {
+		locationID = "global"
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if client, ok := p.clients[locationID]; ok {
+		return client, nil
+	}
+
+	var opts []option.ClientOption
+	if locationID != "global" {
+		endpoint := fmt.Sprintf("parametermanager.%s.rep.googleapis.com:443", locationID)
+		opts = append(opts, option.WithEndpoint(endpoint))
+	}
+	client, err := p.newClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Parameter Manager client for %s: %w", locationID, err)
+	}
+	p.clients[locationID] = client
+	return client, nil
+}
+
+// Close closes every client in the pool. The pool can be used again afterwards and
+// creates new clients on demand.
+func (p *ClientPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for locationID, client := range p.clients {
+		if err := client.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close client for %s: %w", locationID, err))
+		}
+		delete(p.clients, locationID)
+	}
+	return errors.Join(errs...)
+}
+
+// renderWithPool renders a parameter version from the global location and from a
+// regional location through a single ClientPool, using the Parameter Manager SDK for
+// GCP.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+// locationID: The ID of the region where the regional parameter is located.
+// parameterID: The ID of the parameter in both locations.
+// versionID: The ID of the version to be rendered in both locations.
+//
+// The function returns an error if creating a client or either render fails.
+func renderWithPool(w io.Writer, projectID, locationID, parameterID, versionID string) error {
+	ctx := context.Background()
+
+	// Create a pool and close all of its clients when done.
+	pool := NewClientPool()
+	defer pool.Close()
+
+	for _, location := range []string{"global", locationID} {
+		// The pool creates the client for each location on first use.
+		client, err := pool.Client(ctx, location)
+		if err != nil {
+			return err
+		}
+
+		// Construct the name of the parameter version in this location.
+		name := fmt.Sprintf("projects/%s/locations/%s/parameters/%s/versions/%s", projectID, location, parameterID, versionID)
+
+		// Call the API to render the parameter version.
+		resp, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+			Name: name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to render parameter version %s: %w", name, err)
+		}
+
+		fmt.Fprintf(w, "Rendered %s: %s\n", name, resp.RenderedPayload)
+	}
+	return nil
+}
+
+// [END parametermanager_client_pool]
@@ -0,0 +1,667 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"golang.org/x/time/rate"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeParameterClient is an in-memory ParameterClient for tests that must not reach the API.
+// It stores parameters by name and records the requests it receives. Methods it does not
+// override panic through the nil embedded interface. It is safe for concurrent use; tests
+// read the recorded requests only after the code under test has returned.
+type fakeParameterClient struct {
+	ParameterClient
+
+	// mu guards the fields below, which samples that fan out calls across goroutines
+	// read and write concurrently.
+	mu sync.Mutex
+
+	parameters map[string]*parametermanagerpb.Parameter
+
+	// getParameterErr, if set, is returned by every GetParameter call.
+	getParameterErr error
+
+	createParameterReqs []*parametermanagerpb.CreateParameterRequest
+	updateParameterReqs []*parametermanagerpb.UpdateParameterRequest
+
+	versions map[string]*parametermanagerpb.ParameterVersion
+
+	// getParameterVersionPayload, if set, replaces the payload returned by every
+	// GetParameterVersion call.
+	getParameterVersionPayload []byte
+
+	createParameterVersionReqs []*parametermanagerpb.CreateParameterVersionRequest
+
+	// renderedPayloads maps version names to the payload RenderParameterVersion returns.
+	renderedPayloads map[string][]byte
+	// renderParameterVersionCalls counts the RenderParameterVersion calls.
+	renderParameterVersionCalls int
+	// renderDelay, if set, is how long every RenderParameterVersion call takes.
+	renderDelay time.Duration
+}
+
+// newFakeParameterClient returns a fakeParameterClient holding the given parameters.
+func newFakeParameterClient(parameters ...*parametermanagerpb.Parameter) *fakeParameterClient {
+	f := &fakeParameterClient{
+		parameters:       make(map[string]*parametermanagerpb.Parameter),
+		versions:         make(map[string]*parametermanagerpb.ParameterVersion),
+		renderedPayloads: make(map[string][]byte),
+	}
+	for _, p := range parameters {
+		f.parameters[p.Name] = p
+	}
+	return f
+}
+
+func (f *fakeParameterClient) CreateParameter(ctx context.Context, req *parametermanagerpb.CreateParameterRequest, opts ...gax.CallOption) (*parametermanagerpb.Parameter, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.createParameterReqs = append(f.createParameterReqs, req)
+
+	name := fmt.Sprintf("%s/parameters/%s", req.Parent, req.ParameterId)
+	if _, ok := f.parameters[name]; ok {
+		return nil, grpcstatus.Errorf(grpccodes.AlreadyExists, "parameter %s already exists", name)
+	}
+	parameter := proto.Clone(req.Parameter).(*parametermanagerpb.Parameter)
+	parameter.Name = name
+	f.parameters[name] = parameter
+	return proto.Clone(parameter).(*parametermanagerpb.Parameter), nil
+}
+
+func (f *fakeParameterClient) GetParameter(ctx context.Context, req *parametermanagerpb.GetParameterRequest, opts ...gax.CallOption) (*parametermanagerpb.Parameter, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.getParameterErr != nil {
+		return nil, f.getParameterErr
+	}
+	parameter, ok := f.parameters[req.Name]
+	if !ok {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter %s not found", req.Name)
+	}
+	return proto.Clone(parameter).(*parametermanagerpb.Parameter), nil
+}
+
+func (f *fakeParameterClient) UpdateParameter(ctx context.Context, req *parametermanagerpb.UpdateParameterRequest, opts ...gax.CallOption) (*parametermanagerpb.Parameter, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.updateParameterReqs = append(f.updateParameterReqs, req)
+
+	parameter, ok := f.parameters[req.Parameter.Name]
+	if !ok {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter %s not found", req.Parameter.Name)
+	}
+	for _, path := range req.UpdateMask.GetPaths() {
+		switch path {
+		case "format":
+			parameter.Format = req.Parameter.Format
+		case "labels":
+			parameter.Labels = req.Parameter.Labels
+		case "kms_key":
+			parameter.KmsKey = req.Parameter.KmsKey
+		default:
+			return nil, grpcstatus.Errorf(grpccodes.InvalidArgument, "unsupported update mask path %q", path)
+		}
+	}
+	return proto.Clone(parameter).(*parametermanagerpb.Parameter), nil
+}
+
+func (f *fakeParameterClient) CreateParameterVersion(ctx context.Context, req *parametermanagerpb.CreateParameterVersionRequest, opts ...gax.CallOption) (*parametermanagerpb.ParameterVersion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.createParameterVersionReqs = append(f.createParameterVersionReqs, req)
+
+	if _, ok := f.parameters[req.Parent]; !ok {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter %s not found", req.Parent)
+	}
+	version := proto.Clone(req.ParameterVersion).(*parametermanagerpb.ParameterVersion)
+	version.Name = fmt.Sprintf("%s/versions/%s", req.Parent, req.ParameterVersionId)
+	if _, ok := f.versions[version.Name]; ok {
+		return nil, grpcstatus.Errorf(grpccodes.AlreadyExists, "parameter version %s already exists", version.Name)
+	}
+	f.versions[version.Name] = version
+	return proto.Clone(version).(*parametermanagerpb.ParameterVersion), nil
+}
+
+func (f *fakeParameterClient) GetParameterVersion(ctx context.Context, req *parametermanagerpb.GetParameterVersionRequest, opts ...gax.CallOption) (*parametermanagerpb.ParameterVersion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	version, ok := f.versions[req.Name]
+	if !ok {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter version %s not found", req.Name)
+	}
+	version = proto.Clone(version).(*parametermanagerpb.ParameterVersion)
+	if f.getParameterVersionPayload != nil {
+		version.Payload = &parametermanagerpb.ParameterVersionPayload{Data: f.getParameterVersionPayload}
+	}
+	return version, nil
+}
+
+func (f *fakeParameterClient) RenderParameterVersion(ctx context.Context, req *parametermanagerpb.RenderParameterVersionRequest, opts ...gax.CallOption) (*parametermanagerpb.RenderParameterVersionResponse, error) {
+	f.mu.Lock()
+	f.renderParameterVersionCalls++
+	delay := f.renderDelay
+	payload, ok := f.renderedPayloads[req.Name]
+	f.mu.Unlock()
+
+	// Sleep without holding mu, so concurrent renders overlap as they would against the API.
+	time.Sleep(delay)
+	if !ok {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter version %s not found", req.Name)
+	}
+	return &parametermanagerpb.RenderParameterVersionResponse{
+		ParameterVersion: req.Name,
+		RenderedPayload:  payload,
+	}, nil
+}
+
+// TestUpdateParamKmsKeyWithFake tests the updateParamKmsKeyWithClient function against a fake
+// client and verifies the request updates exactly the kms_key field.
+func TestUpdateParamKmsKeyWithFake(t *testing.T) {
+	name := "projects/project/locations/global/parameters/parameter"
+	kmsKey := "projects/project/locations/global/keyRings/ring/cryptoKeys/key"
+	client := newFakeParameterClient(&parametermanagerpb.Parameter{Name: name})
+
+	var buf bytes.Buffer
+	if err := updateParamKmsKeyWithClient(context.Background(), client, &buf, "project", "parameter", kmsKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(client.updateParameterReqs) != 1 {
+		t.Fatalf("updateParamKmsKey: expected 1 UpdateParameter call, got %d", len(client.updateParameterReqs))
+	}
+	req := client.updateParameterReqs[0]
+	if got, want := req.UpdateMask.GetPaths(), []string{"kms_key"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("updateParamKmsKey: expected update mask %v, got %v", want, got)
+	}
+	if got := req.Parameter.GetKmsKey(); got != kmsKey {
+		t.Errorf("updateParamKmsKey: expected kms_key %q, got %q", kmsKey, got)
+	}
+	if got, want := buf.String(), "with kms_key "+kmsKey; !strings.Contains(got, want) {
+		t.Errorf("updateParamKmsKey: expected %q to contain %q", got, want)
+	}
+}
+
+// TestCreateParamWithFake tests the createParamWithClient function against a fake client
+// and verifies an UNFORMATTED parameter is requested under the global location.
+func TestCreateParamWithFake(t *testing.T) {
+	client := newFakeParameterClient()
+
+	var buf bytes.Buffer
+	if err := createParamWithClient(context.Background(), client, &buf, "project", "parameter"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(client.createParameterReqs) != 1 {
+		t.Fatalf("createParam: expected 1 CreateParameter call, got %d", len(client.createParameterReqs))
+	}
+	req := client.createParameterReqs[0]
+	if got, want := req.Parent, "projects/project/locations/global"; got != want {
+		t.Errorf("createParam: expected parent %q, got %q", want, got)
+	}
+	if got, want := req.Parameter.Format, parametermanagerpb.ParameterFormat_UNFORMATTED; got != want {
+		t.Errorf("createParam: expected format %v, got %v", want, got)
+	}
+}
+
+// TestGetParamWithFake tests the getParamWithClient function against a fake client and
+// verifies the not-found error names the parameter.
+func TestGetParamWithFake(t *testing.T) {
+	client := newFakeParameterClient()
+
+	err := getParamWithClient(context.Background(), client, &bytes.Buffer{}, "project", "missing")
+	if err == nil {
+		t.Fatal("getParam: expected an error for a missing parameter")
+	}
+	if got, want := err.Error(), "parameter missing not found"; got != want {
+		t.Errorf("getParam: expected %q, got %q", want, got)
+	}
+}
+
+// TestParamExistsPermissionDenied tests the paramExistsWithClient function against a fake
+// client that denies access and verifies the error is returned rather than reported as a
+// missing parameter.
+func TestParamExistsPermissionDenied(t *testing.T) {
+	client := newFakeParameterClient()
+	client.getParameterErr = grpcstatus.Error(grpccodes.PermissionDenied, "permission denied")
+
+	exists, err := paramExistsWithClient(context.Background(), client, "project", "parameter")
+	if got, want := grpcstatus.Code(err), grpccodes.PermissionDenied; got != want {
+		t.Errorf("paramExists: expected code %v, got %v", want, got)
+	}
+	if exists {
+		t.Errorf("paramExists: expected false alongside an error")
+	}
+}
+
+// TestCreateJSONVersionValidatedWithFake tests the createJSONVersionValidatedWithClient function
+// against a fake client and verifies a valid payload is created while an invalid one is
+// rejected without any RPC.
+func TestCreateJSONVersionValidatedWithFake(t *testing.T) {
+	name := "projects/project/locations/global/parameters/parameter"
+	client := newFakeParameterClient(&parametermanagerpb.Parameter{Name: name})
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	if err := createJSONVersionValidatedWithClient(ctx, client, &buf, "project", "parameter", "v1", []byte(`{"a": 1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), name+"/versions/v1"; !strings.Contains(got, want) {
+		t.Errorf("createJSONVersionValidated: expected %q to contain %q", got, want)
+	}
+
+	err := createJSONVersionValidatedWithClient(ctx, client, &buf, "project", "parameter", "v2", []byte(`{"a": `))
+	if err == nil || err.Error() != "payload is not valid JSON" {
+		t.Errorf("createJSONVersionValidated: expected a payload is not valid JSON error, got %v", err)
+	}
+	if got := len(client.createParameterVersionReqs); got != 1 {
+		t.Errorf("createJSONVersionValidated: expected 1 CreateParameterVersion call, got %d", got)
+	}
+}
+
+// TestCreateYAMLVersionValidatedWithFake tests the createYAMLVersionValidatedWithClient function
+// against a fake client and verifies a single document is created while malformed, empty
+// and multi-document payloads are rejected without any RPC.
+func TestCreateYAMLVersionValidatedWithFake(t *testing.T) {
+	name := "projects/project/locations/global/parameters/parameter"
+	client := newFakeParameterClient(&parametermanagerpb.Parameter{Name: name})
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	if err := createYAMLVersionValidatedWithClient(ctx, client, &buf, "project", "parameter", "v1", []byte("db:\n  host: example\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), name+"/versions/v1"; !strings.Contains(got, want) {
+		t.Errorf("createYAMLVersionValidated: expected %q to contain %q", got, want)
+	}
+
+	for payload, want := range map[string]string{
+		"a: 1\n---\nb: 2\n": "only a single document is allowed",
+		"a: [1, 2\n":        "payload is not valid YAML",
+		"":                  "payload is empty",
+	} {
+		err := createYAMLVersionValidatedWithClient(ctx, client, &buf, "project", "parameter", "v2", []byte(payload))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("createYAMLVersionValidated(%q): expected an error containing %q, got %v", payload, want, err)
+		}
+	}
+	if got := len(client.createParameterVersionReqs); got != 1 {
+		t.Errorf("createYAMLVersionValidated: expected 1 CreateParameterVersion call, got %d", got)
+	}
+}
+
+// TestRenderCache tests the RenderCache type against a fake client and verifies a second
+// Get within the TTL is served from the cache, while expiry and Invalidate trigger a
+// re-render.
+func TestRenderCache(t *testing.T) {
+	name := "projects/project/locations/global/parameters/parameter/versions/v1"
+	client := newFakeParameterClient()
+	client.renderedPayloads[name] = []byte(`{"host": "localhost"}`)
+
+	now := time.Now()
+	cache := NewRenderCache(client, time.Minute)
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	get := func(wantCalls int) {
+		t.Helper()
+		got, err := cache.Get(ctx, "project", "parameter", "v1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"host": "localhost"}`; string(got) != want {
+			t.Errorf("RenderCache.Get: expected %q, got %q", want, got)
+		}
+		if client.renderParameterVersionCalls != wantCalls {
+			t.Errorf("RenderCache.Get: expected %d RenderParameterVersion calls, got %d", wantCalls, client.renderParameterVersionCalls)
+		}
+	}
+
+	get(1)
+	now = now.Add(30 * time.Second)
+	get(1)
+	now = now.Add(31 * time.Second)
+	get(2)
+	cache.Invalidate("project", "parameter", "v1")
+	get(3)
+}
+
+// TestRenderCacheConcurrent tests that concurrent Gets for an uncached version share a
+// single render.
+func TestRenderCacheConcurrent(t *testing.T) {
+	name := "projects/project/locations/global/parameters/parameter/versions/v1"
+	client := newFakeParameterClient()
+	client.renderedPayloads[name] = []byte("value")
+	cache := NewRenderCache(client, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cache.Get(context.Background(), "project", "parameter", "v1"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := client.renderParameterVersionCalls; got != 1 {
+		t.Errorf("RenderCache.Get: expected 1 RenderParameterVersion call, got %d", got)
+	}
+}
+
+// TestRenderCacheError tests that a failed render is returned and not cached.
+func TestRenderCacheError(t *testing.T) {
+	client := newFakeParameterClient()
+	cache := NewRenderCache(client, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.Get(context.Background(), "project", "parameter", "missing")
+		if got, want := grpcstatus.Code(err), grpccodes.NotFound; got != want {
+			t.Errorf("RenderCache.Get: expected code %v, got %v", want, got)
+		}
+	}
+	if got := client.renderParameterVersionCalls; got != 2 {
+		t.Errorf("RenderCache.Get: expected 2 RenderParameterVersion calls, got %d", got)
+	}
+}
+
+// TestCreateVersionWithChecksumWithFake tests the createVersionWithChecksumWithClient function
+// against a fake client and verifies a matching payload is accepted while a truncated
+// stored payload is reported as a checksum mismatch.
+func TestCreateVersionWithChecksumWithFake(t *testing.T) {
+	name := "projects/project/locations/global/parameters/parameter"
+	client := newFakeParameterClient(&parametermanagerpb.Parameter{Name: name})
+	ctx := context.Background()
+	payload := []byte(`{"username": "test-user"}`)
+
+	var buf bytes.Buffer
+	if err := createVersionWithChecksumWithClient(ctx, client, &buf, "project", "parameter", "v1", payload); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), fmt.Sprintf("SHA-256 %x", sha256.Sum256(payload)); !strings.Contains(got, want) {
+		t.Errorf("createVersionWithChecksum: expected %q to contain %q", got, want)
+	}
+
+	client.getParameterVersionPayload = payload[:len(payload)-1]
+	err := createVersionWithChecksumWithClient(ctx, client, &bytes.Buffer{}, "project", "parameter", "v2", payload)
+	if err == nil {
+		t.Fatal("createVersionWithChecksum: expected an error for a truncated stored payload")
+	}
+	if got, want := err.Error(), "checksum mismatch"; !strings.Contains(got, want) {
+		t.Errorf("createVersionWithChecksum: expected %q to contain %q", got, want)
+	}
+}
+
+// fakeClockLimiter is a waiter backed by a real rate.Limiter driven by a fake clock.
+// Wait advances the clock by the delay the limiter asks for instead of sleeping, so
+// elapsed shows how long the calls would have taken in real time.
+type fakeClockLimiter struct {
+	limiter *rate.Limiter
+	now     time.Time
+	elapsed time.Duration
+	waits   int
+}
+
+func newFakeClockLimiter(perSecond float64) *fakeClockLimiter {
+	return &fakeClockLimiter{
+		limiter: rate.NewLimiter(rate.Limit(perSecond), 1),
+		now:     time.Unix(0, 0),
+	}
+}
+
+func (l *fakeClockLimiter) Wait(ctx context.Context) error {
+	delay := l.limiter.ReserveN(l.now, 1).DelayFrom(l.now)
+	l.now = l.now.Add(delay)
+	l.elapsed += delay
+	l.waits++
+	return ctx.Err()
+}
+
+// TestRateLimitedDeleteWithFakeServer tests that rateLimitedDeleteWithClient deletes every
+// parameter after its versions, and waits for the limiter before each delete call.
+func TestRateLimitedDeleteWithFakeServer(t *testing.T) {
+	srv := newFakeParameterManagerServer("project", 4)
+	srv.addVersions("projects/project/locations/global/parameters/parameter-0", "v1", "v2")
+	srv.addVersions("projects/project/locations/global/parameters/parameter-2", "v1")
+	client := newFakeServerClient(t, srv)
+
+	const perSecond = 2
+	limiter := newFakeClockLimiter(perSecond)
+	parameterIDs := []string{"parameter-0", "parameter-1", "parameter-2", "parameter-3"}
+	if err := rateLimitedDeleteWithClient(context.Background(), client, limiter, "project", parameterIDs); err != nil {
+		t.Fatalf("rateLimitedDelete: %v", err)
+	}
+
+	if got := len(srv.parameters); got != 0 {
+		t.Errorf("rateLimitedDelete: expected no parameters left, got %d", got)
+	}
+	// 3 version deletes and 4 parameter deletes.
+	const wantCalls = 7
+	if srv.deleteCalls != wantCalls {
+		t.Errorf("rateLimitedDelete: expected %d delete calls, got %d", wantCalls, srv.deleteCalls)
+	}
+	if limiter.waits != srv.deleteCalls {
+		t.Errorf("rateLimitedDelete: expected a limiter wait per delete call, got %d waits for %d calls", limiter.waits, srv.deleteCalls)
+	}
+	// The first call uses the burst; each later call waits 1/perSecond.
+	if want := (wantCalls - 1) * time.Second / perSecond; limiter.elapsed != want {
+		t.Errorf("rateLimitedDelete: expected calls spread over %v, got %v", want, limiter.elapsed)
+	}
+}
+
+// TestRateLimitedDeletePartialFailure tests that rateLimitedDeleteWithClient keeps deleting
+// after a failure and reports every parameter that failed.
+func TestRateLimitedDeletePartialFailure(t *testing.T) {
+	srv := newFakeParameterManagerServer("project", 2)
+	client := newFakeServerClient(t, srv)
+
+	parameterIDs := []string{"parameter-0", "missing-a", "parameter-1", "missing-b"}
+	err := rateLimitedDeleteWithClient(context.Background(), client, newFakeClockLimiter(10), "project", parameterIDs)
+	if err == nil {
+		t.Fatal("rateLimitedDelete: expected an error for the missing parameters")
+	}
+	for _, id := range []string{"missing-a", "missing-b"} {
+		if !strings.Contains(err.Error(), "parameter "+id+":") {
+			t.Errorf("rateLimitedDelete: expected the error to name %s, got %q", id, err)
+		}
+	}
+	if got := len(srv.parameters); got != 0 {
+		t.Errorf("rateLimitedDelete: expected the existing parameters to be deleted, got %d left", got)
+	}
+}
+
+// TestClientPoolReuse tests that ClientPool creates a single client per location, even for
+// concurrent requests, and a separate client for each location.
+func TestClientPoolReuse(t *testing.T) {
+	pool := NewClientPool()
+	defer pool.Close()
+
+	var mu sync.Mutex
+	created := 0
+	pool.newClient = func(ctx context.Context, opts ...option.ClientOption) (*parametermanager.Client, error) {
+		mu.Lock()
+		created++
+		mu.Unlock()
+		// Connections are established lazily, so no request reaches the endpoint.
+		opts = append(opts,
+			option.WithoutAuthentication(),
+			option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())))
+		return parametermanager.NewClient(ctx, opts...)
+	}
+
+	ctx := context.Background()
+	first, err := pool.Client(ctx, "global")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client, err := pool.Client(ctx, "global")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if client != first {
+				t.Error("ClientPool: expected the global client to be reused")
+			}
+		}()
+	}
+	wg.Wait()
+	if created != 1 {
+		t.Errorf("ClientPool: expected 1 client to be created, got %d", created)
+	}
+
+	regional, err := pool.Client(ctx, "us-central1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if regional == first {
+		t.Error("ClientPool: expected a separate client for us-central1")
+	}
+	if created != 2 {
+		t.Errorf("ClientPool: expected 2 clients to be created, got %d", created)
+	}
+}
+
+// TestCreateLargeVersionWithFake tests that createLargeVersionWithClient reads payloads
+// just under and exactly at the limit from a reader and sends them unchanged, and rejects
+// one byte over the limit without calling CreateParameterVersion.
+func TestCreateLargeVersionWithFake(t *testing.T) {
+	const maxBytes = 1024
+
+	cases := []struct {
+		name    string
+		size    int
+		wantErr bool
+	}{
+		{name: "under the limit", size: maxBytes - 1},
+		{name: "at the limit", size: maxBytes},
+		{name: "over the limit", size: maxBytes + 1, wantErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			name := "projects/project/locations/global/parameters/parameter"
+			client := newFakeParameterClient(&parametermanagerpb.Parameter{Name: name})
+			payload := bytes.Repeat([]byte("abcdefgh"), c.size/8+1)[:c.size]
+
+			var buf bytes.Buffer
+			err := createLargeVersionWithClient(context.Background(), client, &buf, "project", "parameter", "v1", bytes.NewReader(payload), maxBytes)
+			if c.wantErr {
+				if err == nil {
+					t.Fatal("createLargeVersion: expected an error for a payload over the limit")
+				}
+				if got, want := err.Error(), fmt.Sprintf("payload exceeds %d bytes", maxBytes); got != want {
+					t.Errorf("createLargeVersion: expected error %q, got %q", want, got)
+				}
+				if got := len(client.createParameterVersionReqs); got != 0 {
+					t.Errorf("createLargeVersion: expected no CreateParameterVersion call, got %d", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(client.createParameterVersionReqs); got != 1 {
+				t.Fatalf("createLargeVersion: expected 1 CreateParameterVersion call, got %d", got)
+			}
+			if got := client.createParameterVersionReqs[0].GetParameterVersion().GetPayload().GetData(); !bytes.Equal(got, payload) {
+				t.Errorf("createLargeVersion: expected the %d byte payload to be sent unchanged, got %d bytes", len(payload), len(got))
+			}
+			if got, want := buf.String(), fmt.Sprintf("from %d bytes", c.size); !strings.Contains(got, want) {
+				t.Errorf("createLargeVersion: expected %q to contain %q", got, want)
+			}
+		})
+	}
+}
+
+// TestBenchmarkRenderWithFake tests the benchmarkRenderWithClient function against a fake
+// client with a fixed render delay and verifies every percentile line is printed with a
+// non-zero duration.
+func TestBenchmarkRenderWithFake(t *testing.T) {
+	name := "projects/project/locations/global/parameters/parameter/versions/v1"
+	client := newFakeParameterClient()
+	client.renderedPayloads[name] = []byte("value")
+	client.renderDelay = time.Millisecond
+
+	var buf bytes.Buffer
+	if err := benchmarkRenderWithClient(context.Background(), client, &buf, "project", "parameter", "v1", 5); err != nil {
+		t.Fatal(err)
+	}
+	if client.renderParameterVersionCalls != 5 {
+		t.Errorf("benchmarkRender: expected 5 renders, got %d", client.renderParameterVersionCalls)
+	}
+
+	for _, label := range []string{"p50", "p95", "p99", "mean"} {
+		var d time.Duration
+		for _, line := range strings.Split(buf.String(), "\n") {
+			if value, ok := strings.CutPrefix(line, label+": "); ok {
+				var err error
+				if d, err = time.ParseDuration(value); err != nil {
+					t.Fatalf("benchmarkRender: failed to parse %s line %q: %v", label, line, err)
+				}
+			}
+		}
+		if d < client.renderDelay {
+			t.Errorf("benchmarkRender: expected %s of at least %s, got %s in %q", label, client.renderDelay, d, buf.String())
+		}
+	}
+}
+
+// TestBenchmarkRenderCancelled tests the benchmarkRenderWithClient function with a
+// cancelled context and verifies it stops before rendering.
+func TestBenchmarkRenderCancelled(t *testing.T) {
+	client := newFakeParameterClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := benchmarkRenderWithClient(ctx, client, io.Discard, "project", "parameter", "v1", 5)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("benchmarkRender: expected context.Canceled, got %v", err)
+	}
+	if client.renderParameterVersionCalls != 0 {
+		t.Errorf("benchmarkRender: expected no renders, got %d", client.renderParameterVersionCalls)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_compare_regions]
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// compareAcrossRegions renders the same parameter version from two regional endpoints and
+// reports whether the rendered payloads are identical, using the Parameter Manager SDK for GCP.
+//
+// If the payloads differ, the first line that differs is printed for both regions. If the
+// parameter version does not exist in one of the regions, that is reported instead of
+// returning an error, since a missing copy is itself an inconsistency.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+// parameterID: The ID of the parameter in both regions.
+// versionID: The ID of the version to be compared.
+// regionA: The ID of the first region.
+// regionB: The ID of the second region.
+//
+// The function returns an error if creating a client fails or a render fails for any
+// reason other than the parameter version not existing.
+func compareAcrossRegions(w io.Writer, projectID, parameterID, versionID, regionA, regionB string) error {
+	ctx := context.Background()
+
+	regions := []string{regionA, regionB}
+	var payloads [2][]byte
+	var missing []string
+	for i, region := range regions {
+		payload, err := renderInRegion(ctx, projectID, region, parameterID, versionID)
+		if status.Code(err) == codes.NotFound {
+			missing = append(missing, region)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		payloads[i] = payload
+	}
+
+	if len(missing) > 0 {
+		fmt.Fprintf(w, "Parameter version %s/%s not found in %s\n", parameterID, versionID, strings.Join(missing, " and "))
+		return nil
+	}
+
+	if bytes.Equal(payloads[0], payloads[1]) {
+		fmt.Fprintf(w, "Rendered payloads in %s and %s are identical\n", regionA, regionB)
+		return nil
+	}
+
+	// Find the first line that differs and print it as stored in each region.
+	lines := [2][]string{
+		strings.Split(string(payloads[0]), "\n"),
+		strings.Split(string(payloads[1]), "\n"),
+	}
+	line := 0
+	for line < len(lines[0]) && line < len(lines[1]) && lines[0][line] == lines[1][line] {
+		line++
+	}
+	fmt.Fprintf(w, "Rendered payloads in %s and %s differ at line %d\n", regionA, regionB, line+1)
+	for i, region := range regions {
+		if line < len(lines[i]) {
+			fmt.Fprintf(w, "%s: %q\n", region, lines[i][line])
+		} else {
+			fmt.Fprintf(w, "%s: end of payload\n", region)
+		}
+	}
+	return nil
+}
+
+// renderInRegion renders a parameter version through the regional endpoint of region. The
+// returned error keeps the gRPC status of a failed render.
+func renderInRegion(ctx context.Context, projectID, region, parameterID, versionID string) ([]byte, error) {
+	// Create a Parameter Manager client for the regional endpoint.
+	endpoint := fmt.Sprintf("parametermanager.%s.rep.googleapis.com:443", region)
+	client, err := parametermanager.NewClient(ctx, option.WithEndpoint(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Parameter Manager client for %s: %w", region, err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter version in this region.
+	name := fmt.Sprintf("projects/%s/locations/%s/parameters/%s/versions/%s", projectID, region, parameterID, versionID)
+
+	// Call the API to render the parameter version.
+	resp, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render parameter version %s: %w", name, err)
+	}
+	return resp.RenderedPayload, nil
+}
+
+// [END parametermanager_compare_regions]
@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_config_loader]
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ConfigLoader keeps the rendered payload of a parameter's latest enabled version in
+// memory, updated in the background by WatchLatest, so callers can read the current
+// configuration without calling the API.
+//
+// A ConfigLoader is safe for concurrent use. Close must be called to stop the watcher.
+type ConfigLoader struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.RWMutex
+	value   []byte
+	lastErr error
+}
+
+// NewConfigLoader starts watching a parameter and waits until its first payload has been
+// rendered, so that Get never returns an empty configuration. ctx bounds only this wait;
+// the watcher runs until Close is called.
+//
+// ctx: The context that bounds the wait for the first payload.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be watched.
+// interval: How often the parameter is polled for a new version.
+//
+// The function returns an error if ctx is done or the watcher stops before the first
+// payload is rendered; the error includes the last polling error, if there was one.
+func NewConfigLoader(ctx context.Context, projectID, parameterID string, interval time.Duration) (*ConfigLoader, error) {
+	watchCtx, cancel := context.WithCancel(context.Background())
+	l := &ConfigLoader{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	values, errs := WatchLatest(watchCtx, projectID, parameterID, interval)
+	loaded := make(chan struct{})
+	go l.run(values, errs, loaded)
+
+	select {
+	case <-loaded:
+		return l, nil
+	case <-l.done:
+		// The watcher stopped on its own, for example because its client could not be
+		// created, so no payload will arrive.
+		select {
+		case <-loaded:
+			return l, nil
+		default:
+		}
+		l.cancel()
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+		if l.lastErr != nil {
+			return nil, fmt.Errorf("failed to load parameter %s: %w", parameterID, l.lastErr)
+		}
+		return nil, fmt.Errorf("failed to load parameter %s: watcher stopped before the first payload", parameterID)
+	case <-ctx.Done():
+		l.Close(context.Background())
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+		if l.lastErr != nil {
+			return nil, fmt.Errorf("failed to load parameter %s: %w (last error: %v)", parameterID, ctx.Err(), l.lastErr)
+		}
+		return nil, fmt.Errorf("failed to load parameter %s: %w", parameterID, ctx.Err())
+	}
+}
+
+// run stores each payload and error from the watcher until both of its channels are
+// closed, and closes loaded once the first payload is stored.
+func (l *ConfigLoader) run(values <-chan []byte, errs <-chan error, loaded chan<- struct{}) {
+	defer close(l.done)
+
+	first := true
+	for values != nil || errs != nil {
+		select {
+		case value, ok := <-values:
+			if !ok {
+				// Stop selecting on the closed channel and wait for errs to close, so a
+				// final error is not lost.
+				values = nil
+				continue
+			}
+			l.mu.Lock()
+			l.value, l.lastErr = value, nil
+			l.mu.Unlock()
+			if first {
+				close(loaded)
+				first = false
+			}
+		case err, ok := <-errs:
+			if !ok {
+				// Stop selecting on the closed channel and wait for values to close.
+				errs = nil
+				continue
+			}
+			l.mu.Lock()
+			l.lastErr = err
+			l.mu.Unlock()
+		}
+	}
+}
+
+// Get returns the latest rendered payload. The payload may contain resolved secret values.
+func (l *ConfigLoader) Get() []byte {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return bytes.Clone(l.value)
+}
+
+// Close stops the watcher and waits for it to exit. It returns an error if ctx is done
+// first. Close can be called more than once.
+func (l *ConfigLoader) Close(ctx context.Context) error {
+	l.cancel()
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("config loader did not stop: %w", ctx.Err())
+	}
+}
+
+// [END parametermanager_config_loader]
@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_config_server]
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConfigServer serves rendered parameter versions of a project over HTTP, for example
+// from a sidecar so that an application can read its configuration from localhost.
+//
+// Rendered payloads and parameter formats are cached for a fixed TTL, so the API is
+// called at most once per TTL for each version served. The rendered payloads can contain
+// resolved secret values, so the server should only listen on a local address. A
+// ConfigServer is safe for concurrent use.
+type ConfigServer struct {
+	client    ParameterClient
+	projectID string
+	ttl       time.Duration
+	renders   *RenderCache
+
+	mu      sync.Mutex
+	formats map[string]configServerFormat
+}
+
+// configServerFormat holds the cached format of a parameter.
+type configServerFormat struct {
+	format  parametermanagerpb.ParameterFormat
+	expires time.Time
+}
+
+// NewConfigServer returns a ConfigServer that serves the parameters of projectID through
+// client, caching each rendered version for ttl.
+func NewConfigServer(client ParameterClient, projectID string, ttl time.Duration) *ConfigServer {
+	return &ConfigServer{
+		client:    client,
+		projectID: projectID,
+		ttl:       ttl,
+		renders:   NewRenderCache(client, ttl),
+		formats:   make(map[string]configServerFormat),
+	}
+}
+
+// Handler returns an http.Handler that serves GET /config/{parameterID}/{versionID}
+// with the rendered payload of that version. The Content-Type is application/json for
+// JSON parameters, application/yaml for YAML parameters, and application/octet-stream
+// otherwise.
+//
+// Unknown parameters and versions get a 404 response and other failures a 500
+// response. Error responses do not include the underlying error, which is logged.
+func (s *ConfigServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /config/{parameterID}/{versionID}", s.serveConfig)
+	return mux
+}
+
+// serveConfig renders the requested version and writes it to the response.
+func (s *ConfigServer) serveConfig(w http.ResponseWriter, r *http.Request) {
+	parameterID, versionID := r.PathValue("parameterID"), r.PathValue("versionID")
+
+	format, err := s.format(r.Context(), parameterID)
+	if err != nil {
+		s.writeError(w, parameterID, versionID, err)
+		return
+	}
+	payload, err := s.renders.Get(r.Context(), s.projectID, parameterID, versionID)
+	if err != nil {
+		s.writeError(w, parameterID, versionID, err)
+		return
+	}
+
+	switch format {
+	case parametermanagerpb.ParameterFormat_JSON:
+		w.Header().Set("Content-Type", "application/json")
+	case parametermanagerpb.ParameterFormat_YAML:
+		w.Header().Set("Content-Type", "application/yaml")
+	default:
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	// Rendered payloads can hold secrets, so keep them out of shared caches.
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(payload)
+}
+
+// format returns the format of a parameter, from the cache while it is fresh.
+func (s *ConfigServer) format(ctx context.Context, parameterID string) (parametermanagerpb.ParameterFormat, error) {
+	s.mu.Lock()
+	cached, ok := s.formats[parameterID]
+	s.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.format, nil
+	}
+
+	parameter, err := s.client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: fmt.Sprintf("projects/%s/locations/global/parameters/%s", s.projectID, parameterID),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get parameter: %w", err)
+	}
+
+	s.mu.Lock()
+	s.formats[parameterID] = configServerFormat{format: parameter.Format, expires: time.Now().Add(s.ttl)}
+	s.mu.Unlock()
+	return parameter.Format, nil
+}
+
+// writeError logs err and writes a response that does not reveal it.
+func (s *ConfigServer) writeError(w http.ResponseWriter, parameterID, versionID string, err error) {
+	if status.Code(err) == codes.NotFound {
+		http.Error(w, "parameter version not found", http.StatusNotFound)
+		return
+	}
+	log.Printf("config server: %s/%s: %v", parameterID, versionID, err)
+	http.Error(w, "failed to render parameter version", http.StatusInternalServerError)
+}
+
+// [END parametermanager_config_server]
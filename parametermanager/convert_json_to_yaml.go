@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_convert_json_to_yaml]
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"gopkg.in/yaml.v3"
+)
+
+// jsonToYAML converts a JSON document to block-style YAML. JSON is a subset of YAML,
+// so the document is parsed into a yaml.Node tree, which keeps the original key
+// order, and the flow and quoting styles from the JSON syntax are cleared. Strings
+// that would otherwise read as another type, such as "true", stay quoted.
+func jsonToYAML(payload []byte) ([]byte, error) {
+	if !json.Valid(payload) {
+		return nil, errors.New("source payload is not valid JSON")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(payload, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON payload: %w", err)
+	}
+	var clearStyle func(n *yaml.Node)
+	clearStyle = func(n *yaml.Node) {
+		n.Style = 0
+		for _, c := range n.Content {
+			clearStyle(c)
+		}
+	}
+	clearStyle(&doc)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML payload: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML payload: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// convertJSONVersionToYAML reads the JSON payload of a parameter version, converts it to
+// YAML, and stores the result as a new version of the same parameter using the Parameter
+// Manager SDK for GCP. The parameter must have the YAML or UNFORMATTED format, since a
+// JSON parameter rejects YAML payloads.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter holding both versions.
+// srcVersionID: The ID of the version holding the JSON payload.
+// dstVersionID: The ID of the version to be created with the YAML payload.
+//
+// The function returns an error if the parameter has the JSON format, the source payload
+// is not valid JSON, or the version retrieval or creation fails.
+func convertJSONVersionToYAML(w io.Writer, projectID, parameterID, srcVersionID, dstVersionID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Check that the parameter can hold a YAML payload.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: parent,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter: %w", err)
+	}
+	if parameter.Format == parametermanagerpb.ParameterFormat_JSON {
+		return fmt.Errorf("parameter %s has format JSON and cannot store YAML versions: change its format to YAML first", parent)
+	}
+
+	// Get the stored (unrendered) JSON payload of the source version.
+	src, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: fmt.Sprintf("%s/versions/%s", parent, srcVersionID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter version: %w", err)
+	}
+
+	payload, err := jsonToYAML(src.GetPayload().GetData())
+	if err != nil {
+		return fmt.Errorf("failed to convert %s: %w", src.Name, err)
+	}
+
+	// Call the API to create the destination version with the YAML payload.
+	dst, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: dstVersionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	fmt.Fprintf(w, "Converted JSON parameter version %s to YAML parameter version %s\n", src.Name, dst.Name)
+	return nil
+}
+
+// [END parametermanager_convert_json_to_yaml]
@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_copy_param]
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// copyParam copies a parameter and all of its versions to another project using the
+// Parameter Manager SDK for GCP.
+//
+// The format, labels, stored payloads and disabled state are copied. The KMS key is
+// not: Cloud KMS keys belong to a project, so the copy is encrypted with a
+// Google-managed key and a key from the destination project must be set separately
+// if needed. Secret references in payloads are copied verbatim and keep pointing at
+// the secrets they named in the source.
+//
+// w: The io.Writer object used to write the output.
+// srcProjectID: The ID of the project where the parameter is located.
+// dstProjectID: The ID of the project where the parameter is to be copied.
+// parameterID: The ID of the parameter to copy, used in both projects.
+//
+// The function returns an error if reading the source or creating the copy fails.
+func copyParam(w io.Writer, srcProjectID, dstProjectID, parameterID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the names of the source and destination parameters.
+	srcName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", srcProjectID, parameterID)
+	dstParent := fmt.Sprintf("projects/%s/locations/global", dstProjectID)
+
+	// Get the source parameter.
+	src, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: srcName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get source parameter: %w", err)
+	}
+
+	// Create the destination parameter without the source's KMS key.
+	dst, err := client.CreateParameter(ctx, &parametermanagerpb.CreateParameterRequest{
+		Parent:      dstParent,
+		ParameterId: parameterID,
+		Parameter: &parametermanagerpb.Parameter{
+			Format: src.Format,
+			Labels: src.Labels,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create destination parameter: %w", err)
+	}
+
+	// Recreate each source version in the destination parameter.
+	count := 0
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: srcName,
+	})
+	for {
+		listed, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+
+		version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+			Name: listed.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get parameter version: %w", err)
+		}
+
+		if _, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+			Parent:             dst.Name,
+			ParameterVersionId: path.Base(version.Name),
+			ParameterVersion: &parametermanagerpb.ParameterVersion{
+				Disabled: version.Disabled,
+				Payload:  version.Payload,
+			},
+		}); err != nil {
+			return fmt.Errorf("failed to create parameter version %s: %w", path.Base(version.Name), err)
+		}
+		count++
+	}
+
+	fmt.Fprintf(w, "Copied parameter %s to %s with %d versions\n", srcName, dst.Name, count)
+	return nil
+}
+
+// [END parametermanager_copy_param]
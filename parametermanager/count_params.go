@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_count_params]
+import (
+	"context"
+	"fmt"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// countParams counts the parameters in a project using the Parameter Manager SDK for GCP.
+//
+// Parameters are counted as the iterator yields them and are not retained, so only
+// one page is held in memory at a time. The context is checked before each
+// parameter so a cancellation stops the listing promptly.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameters are located.
+//
+// The function returns an error if the listing fails or ctx is done.
+func countParams(ctx context.Context, projectID string) (int, error) {
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return countParamsWithClient(ctx, client, projectID)
+}
+
+// countParamsWithClient lists the parameters of a project through client and counts
+// them as the iterator yields them.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// projectID: The ID of the project where the parameters are located.
+//
+// The function returns the number of parameters, or an error if the listing fails or
+// ctx is done.
+func countParamsWithClient(ctx context.Context, client ParameterClient, projectID string) (int, error) {
+	// Request the largest page size to keep the number of round trips low.
+	parameters := client.ListParameters(ctx, &parametermanagerpb.ListParametersRequest{
+		Parent:   fmt.Sprintf("projects/%s/locations/global", projectID),
+		PageSize: 1000,
+	})
+
+	count := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		_, err := parameters.Next()
+		if err == iterator.Done {
+			return count, nil
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to list parameters: %w", err)
+		}
+		count++
+	}
+}
+
+// [END parametermanager_count_params]
@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_create_and_verify]
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// createAndVerifyRender creates a parameter version and renders it straight away to
+// confirm that every secret reference in the payload resolves, using the Parameter
+// Manager SDK for GCP. A reference resolves only if the secret version exists and the
+// parameter's service identity can access it, so checking at creation time catches
+// mistakes before a workload reads the version.
+//
+// The version is kept if the check fails, so it can be fixed or deleted.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The payload of the version, which may contain secret references.
+//
+// The function returns an error if the parameter version creation or rendering fails, or
+// an error listing the references that are still present in the rendered payload.
+func createAndVerifyRender(w io.Writer, projectID, parameterID, versionID string, payload []byte) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return createAndVerifyRenderWithClient(ctx, client, w, projectID, parameterID, versionID, payload)
+}
+
+// createAndVerifyRenderWithClient creates the version through client, renders it, and
+// checks the rendered payload for secret references that were left unresolved.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The payload of the version, which may contain secret references.
+//
+// The function returns an error if the parameter version creation or rendering fails, or
+// an error listing the references that are still present in the rendered payload.
+func createAndVerifyRenderWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, versionID string, payload []byte) error {
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Call the API to create the parameter version.
+	version, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+	fmt.Fprintf(w, "Created parameter version: %s\n", version.Name)
+
+	// Call the API to render the new version.
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: version.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render parameter version %s: %w", version.Name, err)
+	}
+
+	// Any reference left in the rendered payload was not resolved. The quotes around a
+	// reference are optional and are escaped when it sits inside a JSON string.
+	refRE := regexp.MustCompile(`__REF__\(\s*\\?"?//secretmanager\.googleapis\.com/([^"\\)\s]+)\\?"?\s*\)`)
+	var unresolved []string
+	for _, match := range refRE.FindAllSubmatch(rendered.RenderedPayload, -1) {
+		unresolved = append(unresolved, string(match[1]))
+	}
+	if len(unresolved) > 0 {
+		return fmt.Errorf("rendered payload of %s has unresolved secret references: %s", version.Name, strings.Join(unresolved, ", "))
+	}
+	if bytes.Contains(rendered.RenderedPayload, []byte("__REF__(")) {
+		return fmt.Errorf("rendered payload of %s has unresolved __REF__ tokens", version.Name)
+	}
+
+	fmt.Fprintf(w, "All %d references resolved\n", len(refRE.FindAll(payload, -1)))
+	return nil
+}
+
+// [END parametermanager_create_and_verify]
@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_create_json_version_validated]
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// createJSONVersionValidated creates a parameter version for a JSON parameter after checking
+// the payload is valid JSON, using the Parameter Manager SDK for GCP.
+//
+// The server also rejects invalid JSON for JSON parameters, but the check here fails
+// fast with a clear message and without a round trip.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The JSON payload to be stored in the new parameter version.
+//
+// The function returns an error if the payload is not valid JSON or the version creation fails.
+func createJSONVersionValidated(w io.Writer, projectID, parameterID, versionID string, payload []byte) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return createJSONVersionValidatedWithClient(ctx, client, w, projectID, parameterID, versionID, payload)
+}
+
+// createJSONVersionValidatedWithClient checks that payload is valid JSON and, only if it
+// is, creates the version through client.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The JSON payload to be stored in the new parameter version.
+//
+// The function returns an error if the payload is not valid JSON or the version creation fails.
+func createJSONVersionValidatedWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, versionID string, payload []byte) error {
+	// Validate the payload before calling the API.
+	if !json.Valid(payload) {
+		return errors.New("payload is not valid JSON")
+	}
+
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Build the request to create the parameter version.
+	req := &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	}
+
+	// Call the API to create the parameter version.
+	version, err := client.CreateParameterVersion(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	fmt.Fprintf(w, "Created parameter version: %s\n", version.Name)
+	return nil
+}
+
+// [END parametermanager_create_json_version_validated]
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_create_large_version]
+import (
+	"context"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// createLargeVersion creates a parameter version from a stream, rejecting payloads over a
+// size limit before sending them, using the Parameter Manager SDK for GCP.
+//
+// At most maxBytes+1 bytes are read from r, so an oversized stream is detected without
+// reading it to the end.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// r: The reader the payload is read from.
+// maxBytes: The largest payload accepted, for example maxPayloadBytes.
+//
+// The function returns an error if reading r fails, the payload exceeds maxBytes,
+// or the version creation fails.
+func createLargeVersion(w io.Writer, projectID, parameterID, versionID string, r io.Reader, maxBytes int) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return createLargeVersionWithClient(ctx, client, w, projectID, parameterID, versionID, r, maxBytes)
+}
+
+// createLargeVersionWithClient reads and checks the payload from r, then creates the
+// version from it using the given client.
+//
+// ctx: The context used for the API call.
+// client: The Parameter Manager client used to create the version.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// r: The reader the payload is read from.
+// maxBytes: The largest payload accepted.
+//
+// The function returns an error if reading r fails, the payload exceeds maxBytes,
+// or the version creation fails.
+func createLargeVersionWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, versionID string, r io.Reader, maxBytes int) error {
+	// Read and check the payload before sending anything. Read one byte past the limit
+	// to tell a payload of exactly maxBytes from a larger one.
+	payload, err := io.ReadAll(io.LimitReader(r, int64(maxBytes)+1))
+	if err != nil {
+		return fmt.Errorf("failed to read payload: %w", err)
+	}
+	if len(payload) > maxBytes {
+		return fmt.Errorf("payload exceeds %d bytes", maxBytes)
+	}
+
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Build the request to create the parameter version.
+	req := &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	}
+
+	// Call the API to create the parameter version.
+	version, err := client.CreateParameterVersion(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	fmt.Fprintf(w, "Created parameter version %s from %d bytes\n", version.Name, len(payload))
+	return nil
+}
+
+// [END parametermanager_create_large_version]
@@ -40,14 +40,30 @@ func createParam(w io.Writer, projectID, parameterID string) error {
 	}
 	defer client.Close()
 
+	return createParamWithClient(ctx, client, w, projectID, parameterID)
+}
+
+// createParamWithClient creates an UNFORMATTED parameter through client and prints the
+// name of the created parameter.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be created.
+//
+// The function returns an error if the parameter creation fails.
+func createParamWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID string) error {
 	// Construct the name of the create parameter.
 	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
 
-	// Build the request to create a new parameter
+	// Build the request to create a new parameter with the unformatted format.
 	req := &parametermanagerpb.CreateParameterRequest{
 		Parent:      parent,
 		ParameterId: parameterID,
-		Parameter:   &parametermanagerpb.Parameter{},
+		Parameter: &parametermanagerpb.Parameter{
+			Format: parametermanagerpb.ParameterFormat_UNFORMATTED,
+		},
 	}
 
 	// Call the API to create the parameter.
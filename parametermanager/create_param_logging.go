@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_create_param_with_logger]
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/grpc/status"
+)
+
+// createParamWithLogger creates a new parameter with the format type "unformatted" in
+// Parameter Manager and reports the outcome as structured log records.
+//
+// Successful creations are logged at Info with the operation, resource_name and
+// format fields. Failures are logged at Error with the gRPC code in the code field.
+//
+// ctx: The context used for the API calls and log records.
+// logger: The logger that receives the records.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be created.
+//
+// The function returns an error if the parameter creation fails.
+func createParamWithLogger(ctx context.Context, logger *slog.Logger, projectID, parameterID string) error {
+	const operation = "CreateParameter"
+
+	// Construct the name of the parameter to be created.
+	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
+	name := fmt.Sprintf("%s/parameters/%s", parent, parameterID)
+	format := parametermanagerpb.ParameterFormat_UNFORMATTED
+
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to create Parameter Manager client",
+			slog.String("operation", operation),
+			slog.String("error", err.Error()))
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Call the API to create the parameter.
+	parameter, err := client.CreateParameter(ctx, &parametermanagerpb.CreateParameterRequest{
+		Parent:      parent,
+		ParameterId: parameterID,
+		Parameter: &parametermanagerpb.Parameter{
+			Format: format,
+		},
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to create parameter",
+			slog.String("operation", operation),
+			slog.String("resource_name", name),
+			slog.String("code", status.Code(err).String()),
+			slog.String("error", err.Error()))
+		return fmt.Errorf("failed to create parameter: %w", err)
+	}
+
+	logger.InfoContext(ctx, "created parameter",
+		slog.String("operation", operation),
+		slog.String("resource_name", parameter.Name),
+		slog.String("format", parameter.Format.String()))
+	return nil
+}
+
+// [END parametermanager_create_param_with_logger]
@@ -30,10 +30,10 @@ import (
 // projectID: The ID of the project where the parameter is located.
 // parameterID: The ID of the parameter for which the version is to be created.
 // versionID: The ID of the version to be created.
-// payload: The unformatted string payload to be stored in the new parameter version.
+// payload: The unformatted payload bytes to be stored in the new parameter version.
 //
 // The function returns an error if the parameter version creation fails.
-func createParamVersion(w io.Writer, projectID, parameterID, versionID, payload string) error {
+func createParamVersion(w io.Writer, projectID, parameterID, versionID string, payload []byte) error {
 	// Create a context and a Parameter Manager client.
 	ctx := context.Background()
 	client, err := parametermanager.NewClient(ctx)
@@ -51,7 +51,7 @@ func createParamVersion(w io.Writer, projectID, parameterID, versionID, payload
 		ParameterVersionId: versionID,
 		ParameterVersion: &parametermanagerpb.ParameterVersion{
 			Payload: &parametermanagerpb.ParameterVersionPayload{
-				Data: []byte(payload), // Set the unformatted payload data.
+				Data: payload, // Set the unformatted payload data.
 			},
 		},
 	}
@@ -24,16 +24,18 @@ import (
 	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
 )
 
-// createParamVersionWithSecret creates a new version of a parameter with a JSON payload that has a secret reference in it using the Parameter Manager SDK for GCP.
+// createParamVersionWithSecretRef creates a new version of a parameter with a JSON payload that references
+// the latest version of a Secret Manager secret using the Parameter Manager SDK for GCP. The reference is
+// resolved when the version is rendered, see renderParamVersion.
 //
 // w: The io.Writer object used to write the output.
-// projectID: The ID of the project where the parameter is located.
+// projectID: The ID of the project where the parameter and the secret are located.
 // parameterID: The ID of the parameter for which the version is to be created.
 // versionID: The ID of the version to be created.
 // secretID: The ID of the secret to be referenced.
 //
 // The function returns an error if the parameter version creation fails.
-func createParamVersionWithSecret(w io.Writer, projectID, parameterID, versionID, secretID string) error {
+func createParamVersionWithSecretRef(w io.Writer, projectID, parameterID, versionID, secretID string) error {
 	// Create a context and a Parameter Manager client.
 	ctx := context.Background()
 	client, err := parametermanager.NewClient(ctx)
@@ -45,7 +47,9 @@ func createParamVersionWithSecret(w io.Writer, projectID, parameterID, versionID
 	// Construct the name of the create parameter version.
 	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
 
-	payload := []byte(fmt.Sprintf(`{"username": "test-user","password": "__REF__(//secretmanager.googleapis.com/%s)"}`, secretID))
+	// Reference the latest version of the secret.
+	secretRef := fmt.Sprintf("//secretmanager.googleapis.com/projects/%s/secrets/%s/versions/latest", projectID, secretID)
+	payload := []byte(fmt.Sprintf(`{"db_password": "__REF__(\"%s\")"}`, secretRef))
 
 	// Build the request to create a new parameter version with the JSON payload that has a secret reference.
 	req := &parametermanagerpb.CreateParameterVersionRequest{
@@ -22,9 +22,12 @@ import (
 
 	parametermanager "cloud.google.com/go/parametermanager/apiv1"
 	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// createParamWithKmsKey creates a parameter with kms_key using the Parameter Manager SDK for GCP.
+// createParamWithKmsKey creates a parameter with kms_key using the Parameter Manager SDK for GCP,
+// so the parameter is protected by the customer-managed key from creation.
 //
 // w: The io.Writer object used to write the output.
 // projectID: The ID of the project where the parameter is located.
@@ -59,6 +62,11 @@ func createParamWithKmsKey(w io.Writer, projectID, parameterID, kmsKey string) e
 	}
 	parameter, err := client.CreateParameter(ctx, req)
 	if err != nil {
+		// The Parameter Manager service agent needs to be able to use the key.
+		if status.Code(err) == codes.PermissionDenied {
+			return fmt.Errorf("failed to create parameter: grant the Parameter Manager service agent "+
+				"roles/cloudkms.cryptoKeyEncrypterDecrypter on %s: %w", kmsKey, err)
+		}
 		return fmt.Errorf("failed to create parameter: %w", err)
 	}
 
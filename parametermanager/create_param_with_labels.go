@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_create_param_with_labels]
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// createParamWithLabels creates a parameter with labels using the Parameter Manager SDK for GCP.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is to be created.
+// parameterID: The ID of the parameter to be created.
+// labels: The labels to attach to the parameter.
+//
+// The function returns an error if a label is invalid or the parameter creation fails.
+func createParamWithLabels(w io.Writer, projectID, parameterID string, labels map[string]string) error {
+	// Validate the labels before calling the API, against the constraints GCP enforces
+	// on resource labels.
+	labelKeyRE := regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	labelValueRE := regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+	for key, value := range labels {
+		if !labelKeyRE.MatchString(key) {
+			return fmt.Errorf("invalid label key %q: must start with a lowercase letter and contain at most 63 lowercase letters, digits, underscores, or dashes", key)
+		}
+		if !labelValueRE.MatchString(value) {
+			return fmt.Errorf("invalid value %q for label %q: must contain at most 63 lowercase letters, digits, underscores, or dashes", value, key)
+		}
+	}
+
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the create parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
+
+	// Build the request to create a new parameter with labels.
+	req := &parametermanagerpb.CreateParameterRequest{
+		Parent:      parent,
+		ParameterId: parameterID,
+		Parameter: &parametermanagerpb.Parameter{
+			Format: parametermanagerpb.ParameterFormat_UNFORMATTED,
+			Labels: labels,
+		},
+	}
+
+	// Call the API to create the parameter.
+	parameter, err := client.CreateParameter(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create parameter: %w", err)
+	}
+
+	fmt.Fprintf(w, "Created parameter %s with %d labels\n", parameter.Name, len(parameter.Labels))
+	return nil
+}
+
+// [END parametermanager_create_param_with_labels]
@@ -31,8 +31,17 @@ import (
 // parameterID: The ID of the parameter to be created.
 // format: The format type of the parameter (UNFORMATTED, YAML, JSON).
 //
-// The function returns an error if the parameter creation fails.
+// The function returns an error if the format is invalid or the parameter creation fails.
 func createStructuredParam(w io.Writer, projectID, parameterID string, format parametermanagerpb.ParameterFormat) error {
+	// Reject formats the API does not accept before issuing the request.
+	switch format {
+	case parametermanagerpb.ParameterFormat_UNFORMATTED,
+		parametermanagerpb.ParameterFormat_YAML,
+		parametermanagerpb.ParameterFormat_JSON:
+	default:
+		return fmt.Errorf("invalid parameter format %s: must be one of UNFORMATTED, YAML, or JSON", format.String())
+	}
+
 	// Create a context and a Parameter Manager client.
 	ctx := context.Background()
 	client, err := parametermanager.NewClient(ctx)
@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_create_version_from_base64]
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// createVersionFromBase64 creates a parameter version from a base64-encoded payload using the
+// Parameter Manager SDK for GCP, so binary data can be passed as text. The input uses the
+// standard base64 alphabet with padding.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// b64: The base64-encoded payload.
+//
+// The function returns an error if the input is not valid base64 or the version creation fails.
+func createVersionFromBase64(w io.Writer, projectID, parameterID, versionID, b64 string) error {
+	// Decode the payload before creating a client.
+	payload, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return fmt.Errorf("payload is not valid base64: %w", err)
+	}
+
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Call the API to create the parameter version with the decoded bytes.
+	version, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	fmt.Fprintf(w, "Created parameter version %s from %d decoded bytes\n", version.Name, len(payload))
+	return nil
+}
+
+// [END parametermanager_create_version_from_base64]
@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_create_version_with_checksum]
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// createVersionWithChecksum creates a parameter version and verifies the stored payload
+// against a SHA-256 checksum of the local payload using the Parameter Manager SDK for GCP.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The payload to be stored in the new parameter version.
+//
+// The function returns an error if the version creation or retrieval fails, or if the
+// stored payload does not match the local payload.
+func createVersionWithChecksum(w io.Writer, projectID, parameterID, versionID string, payload []byte) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return createVersionWithChecksumWithClient(ctx, client, w, projectID, parameterID, versionID, payload)
+}
+
+// createVersionWithChecksumWithClient creates the version through client, reads it back,
+// and compares the SHA-256 of the stored payload with that of payload.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The payload to be stored in the new parameter version.
+//
+// The function returns an error if the version creation or retrieval fails, or if the
+// stored payload does not match the local payload.
+func createVersionWithChecksumWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, versionID string, payload []byte) error {
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Call the API to create the parameter version.
+	created, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	// Read the version back and compare checksums of the stored and local payloads.
+	stored, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: created.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter version: %w", err)
+	}
+
+	want := sha256.Sum256(payload)
+	got := sha256.Sum256(stored.GetPayload().GetData())
+	if got != want {
+		return fmt.Errorf("checksum mismatch for parameter version %s: stored SHA-256 %x, local SHA-256 %x", created.Name, got, want)
+	}
+
+	fmt.Fprintf(w, "Created parameter version %s with verified SHA-256 %x\n", created.Name, got)
+	return nil
+}
+
+// [END parametermanager_create_version_with_checksum]
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_create_version_from_file]
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// maxPayloadBytes is the largest payload Parameter Manager accepts for a parameter version (1 MiB).
+const maxPayloadBytes = 1 << 20
+
+// createVersionFromFile creates a parameter version whose payload is the contents of a local
+// file using the Parameter Manager SDK for GCP.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// filePath: The path of the file holding the payload.
+//
+// The function returns an error if the file cannot be read, is larger than the
+// payload limit, or the version creation fails.
+func createVersionFromFile(w io.Writer, projectID, parameterID, versionID, filePath string) error {
+	// Check the file size before reading it into memory.
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat payload file: %w", err)
+	}
+	if info.Size() > maxPayloadBytes {
+		return fmt.Errorf("payload file %s is %d bytes, larger than the %d byte limit for a parameter version", filePath, info.Size(), maxPayloadBytes)
+	}
+
+	payload, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read payload file: %w", err)
+	}
+
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Build the request to create the parameter version with the file's contents.
+	req := &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	}
+
+	// Call the API to create the parameter version.
+	version, err := client.CreateParameterVersion(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	fmt.Fprintf(w, "Created parameter version %s from %d bytes\n", version.Name, len(payload))
+	return nil
+}
+
+// [END parametermanager_create_version_from_file]
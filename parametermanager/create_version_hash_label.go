@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_create_version_hash_label]
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+// latestHashLabel is the parameter label that holds the content hash of the version
+// created last by createVersionWithHashLabel.
+const latestHashLabel = "latest_hash"
+
+// createVersionWithHashLabel creates a parameter version and sets the "latest_hash" label
+// of the parameter to the first 12 hex characters of the payload's SHA-256, using the
+// Parameter Manager SDK for GCP. Readers can then detect a changed value by reading the
+// parameter alone, without fetching any payload.
+//
+// The label is set after the version is created, with a read-modify-write of the
+// parameter's labels, so concurrent callers can leave the label on either payload.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The payload of the version.
+//
+// The function returns an error if the parameter version creation or the label update
+// fails.
+func createVersionWithHashLabel(w io.Writer, projectID, parameterID, versionID string, payload []byte) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return createVersionWithHashLabelWithClient(ctx, client, w, projectID, parameterID, versionID, payload)
+}
+
+// createVersionWithHashLabelWithClient creates the version through client, then reads
+// the parameter and updates its labels to set latestHashLabel.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The payload of the version.
+//
+// The function returns an error if the parameter version creation or the label update
+// fails.
+func createVersionWithHashLabelWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, versionID string, payload []byte) error {
+	// Call the API to create the parameter version.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	version, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             name,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+	fmt.Fprintf(w, "Created parameter version: %s\n", version.Name)
+
+	// Hex digits are valid label values, and 12 of them are enough to detect a change.
+	sum := sha256.Sum256(payload)
+	hash := hex.EncodeToString(sum[:])[:12]
+
+	// Set the label, keeping the other labels already on the parameter.
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter: %w", err)
+	}
+	labels := maps.Clone(parameter.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[latestHashLabel] = hash
+	if _, err := client.UpdateParameter(ctx, &parametermanagerpb.UpdateParameterRequest{
+		Parameter: &parametermanagerpb.Parameter{
+			Name:   name,
+			Labels: labels,
+		},
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"labels"},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to set %s label: %w", latestHashLabel, err)
+	}
+
+	fmt.Fprintf(w, "Set label %s=%s on parameter %s\n", latestHashLabel, hash, name)
+	return nil
+}
+
+// [END parametermanager_create_version_hash_label]
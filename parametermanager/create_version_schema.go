@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_create_version_schema]
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// validateSchema checks that payload is a JSON document conforming to the JSON Schema in
+// schema. A payload that does not conform gets an error listing each violation with the
+// location of the offending field.
+func validateSchema(payload, schema []byte) error {
+	compiled, err := jsonschema.CompileString("schema.json", string(schema))
+	if err != nil {
+		return fmt.Errorf("failed to compile schema: %w", err)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(payload, &doc); err != nil {
+		return fmt.Errorf("payload is not valid JSON: %w", err)
+	}
+
+	err = compiled.Validate(doc)
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+
+	// Report only the leaves of the error tree; the inner nodes just group them.
+	var violations []string
+	var collect func(*jsonschema.ValidationError)
+	collect = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			location := e.InstanceLocation
+			if location == "" {
+				location = "/"
+			}
+			violations = append(violations, fmt.Sprintf("%s: %s", location, e.Message))
+		}
+		for _, cause := range e.Causes {
+			collect(cause)
+		}
+	}
+	collect(verr)
+	return fmt.Errorf("payload does not match schema:\n  %s", strings.Join(violations, "\n  "))
+}
+
+// createVersionWithSchema creates a parameter version after validating its payload against
+// a JSON Schema, using the Parameter Manager SDK for GCP.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The JSON payload to be stored in the new parameter version.
+// schema: The JSON Schema the payload must conform to.
+//
+// The function returns an error if the schema is invalid, the payload does not conform
+// to it, or the version creation fails.
+func createVersionWithSchema(w io.Writer, projectID, parameterID, versionID string, payload, schema []byte) error {
+	// Validate the payload before creating a client.
+	if err := validateSchema(payload, schema); err != nil {
+		return err
+	}
+
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Build the request to create the parameter version.
+	req := &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	}
+
+	// Call the API to create the parameter version.
+	version, err := client.CreateParameterVersion(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	fmt.Fprintf(w, "Created parameter version: %s\n", version.Name)
+	return nil
+}
+
+// [END parametermanager_create_version_schema]
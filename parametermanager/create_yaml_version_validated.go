@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_create_yaml_version_validated]
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"gopkg.in/yaml.v3"
+)
+
+// validateYAML checks that payload holds exactly one well-formed YAML document.
+func validateYAML(payload []byte) error {
+	dec := yaml.NewDecoder(bytes.NewReader(payload))
+	var doc interface{}
+	if err := dec.Decode(&doc); errors.Is(err, io.EOF) {
+		return errors.New("payload is empty: a YAML document is required")
+	} else if err != nil {
+		return fmt.Errorf("payload is not valid YAML: %w", err)
+	}
+	if err := dec.Decode(&doc); !errors.Is(err, io.EOF) {
+		return errors.New("payload contains multiple YAML documents: only a single document is allowed")
+	}
+	return nil
+}
+
+// createYAMLVersionValidated creates a parameter version for a YAML parameter after checking
+// the payload is a single valid YAML document, using the Parameter Manager SDK for GCP.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The YAML payload to be stored in the new parameter version.
+//
+// The function returns an error if the payload is empty or not a single valid YAML
+// document, or if the version creation fails.
+func createYAMLVersionValidated(w io.Writer, projectID, parameterID, versionID string, payload []byte) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return createYAMLVersionValidatedWithClient(ctx, client, w, projectID, parameterID, versionID, payload)
+}
+
+// createYAMLVersionValidatedWithClient checks payload with validateYAML and, only if it
+// passes, creates the version through client.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The YAML payload to be stored in the new parameter version.
+//
+// The function returns an error if the payload is empty or not a single valid YAML
+// document, or if the version creation fails.
+func createYAMLVersionValidatedWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, versionID string, payload []byte) error {
+	// Validate the payload before calling the API.
+	if err := validateYAML(payload); err != nil {
+		return err
+	}
+
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Build the request to create the parameter version.
+	req := &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	}
+
+	// Call the API to create the parameter version.
+	version, err := client.CreateParameterVersion(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	fmt.Fprintf(w, "Created parameter version: %s\n", version.Name)
+	return nil
+}
+
+// [END parametermanager_create_yaml_version_validated]
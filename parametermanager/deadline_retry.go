@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_retry_within_deadline]
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Retry settings used by withDeadlineRetry.
+const (
+	// deadlineRetryMinAttempt is the smallest time budget worth starting an attempt with.
+	deadlineRetryMinAttempt = 10 * time.Millisecond
+	// deadlineRetryBaseDelay is the delay before the first retry; it doubles on each retry.
+	deadlineRetryBaseDelay = 200 * time.Millisecond
+	// deadlineRetryMaxDelay caps the delay between two attempts.
+	deadlineRetryMaxDelay = 5 * time.Second
+	// deadlineRetryMaxAttempts is the total number of attempts, including the first one,
+	// when ctx has no deadline.
+	deadlineRetryMaxAttempts = 5
+)
+
+// withDeadlineRetry calls op until it succeeds, retrying transient Parameter Manager errors
+// without running past the deadline of ctx.
+//
+// Errors with code Unavailable, DeadlineExceeded or ResourceExhausted are retried with
+// capped exponential backoff and full jitter. Each attempt gets a context whose deadline
+// is half of the remaining budget, so a slow attempt still leaves room for another one;
+// once the remaining budget gets too small to split, the next attempt gets all of it.
+// The backoff is cut short so that it never eats into the last attempt. If ctx has no
+// deadline, op is called with ctx itself, up to deadlineRetryMaxAttempts times.
+//
+// ctx: The context whose deadline bounds all attempts and the waits between them.
+// op: The operation to call with the context for a single attempt.
+//
+// The function returns the last error from op once the budget is exhausted, or the
+// context error if ctx is cancelled while waiting to retry.
+func withDeadlineRetry(ctx context.Context, op func(context.Context) error) error {
+	deadline, hasDeadline := ctx.Deadline()
+
+	delay := deadlineRetryBaseDelay
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if hasDeadline {
+			remaining := time.Until(deadline)
+			budget := remaining / 2
+			if budget < deadlineRetryMinAttempt {
+				budget = remaining
+			}
+			attemptCtx, cancel = context.WithTimeout(ctx, budget)
+		}
+		err := op(attemptCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		// Retry transient codes, and an attempt that ran out of its own sub-deadline.
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		default:
+			if !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+				return err
+			}
+		}
+
+		// Wait for a random duration up to the current delay. With a deadline, stop if
+		// there is no room left for another attempt after the wait.
+		wait := time.Duration(rand.Int63n(int64(delay) + 1))
+		if hasDeadline {
+			if room := time.Until(deadline) - deadlineRetryMinAttempt; wait > room {
+				wait = room
+			}
+			if wait < 0 {
+				return err
+			}
+		} else if attempt >= deadlineRetryMaxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		delay *= 2
+		if delay > deadlineRetryMaxDelay {
+			delay = deadlineRetryMaxDelay
+		}
+	}
+}
+
+// [END parametermanager_retry_within_deadline]
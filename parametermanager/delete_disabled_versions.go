@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_delete_disabled_versions]
+import (
+	"context"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// deleteDisabledVersions deletes the disabled versions of a parameter using the Parameter
+// Manager SDK for GCP.
+//
+// A parameter is never left without versions: if every version is disabled, the
+// most recently created one is kept and a notice is printed.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose disabled versions are to be deleted.
+// dryRun: Whether to only print the versions that would be deleted.
+//
+// The function returns an error if listing or deleting the versions fails.
+func deleteDisabledVersions(w io.Writer, projectID, parameterID string, dryRun bool) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter whose versions are listed.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Collect the disabled versions, counting all versions along the way.
+	total := 0
+	var disabled []*parametermanagerpb.ParameterVersion
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: parent,
+	})
+	for {
+		version, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		total++
+		if version.Disabled {
+			disabled = append(disabled, version)
+		}
+	}
+
+	// Keep the newest version if deleting every disabled one would leave none.
+	if len(disabled) > 0 && len(disabled) == total {
+		newest := 0
+		for i, version := range disabled {
+			if version.GetCreateTime().AsTime().After(disabled[newest].GetCreateTime().AsTime()) {
+				newest = i
+			}
+		}
+		fmt.Fprintf(w, "Keeping parameter version %s so that the parameter is not left without versions\n", disabled[newest].Name)
+		disabled = append(disabled[:newest], disabled[newest+1:]...)
+	}
+
+	for _, version := range disabled {
+		if dryRun {
+			fmt.Fprintf(w, "Would delete parameter version %s\n", version.Name)
+			continue
+		}
+		if err := client.DeleteParameterVersion(ctx, &parametermanagerpb.DeleteParameterVersionRequest{
+			Name: version.Name,
+		}); err != nil {
+			return fmt.Errorf("failed to delete parameter version %s: %w", version.Name, err)
+		}
+		fmt.Fprintf(w, "Deleted parameter version %s\n", version.Name)
+	}
+
+	if dryRun {
+		fmt.Fprintf(w, "Would delete %d disabled parameter versions\n", len(disabled))
+	} else {
+		fmt.Fprintf(w, "Deleted %d disabled parameter versions\n", len(disabled))
+	}
+	return nil
+}
+
+// [END parametermanager_delete_disabled_versions]
@@ -22,6 +22,8 @@ import (
 
 	parametermanager "cloud.google.com/go/parametermanager/apiv1"
 	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // deleteParam deletes a parameter using the Parameter Manager SDK for GCP.
@@ -53,6 +55,10 @@ func deleteParam(w io.Writer, projectID, parameterID string) error {
 	// Call the API to delete the parameter.
 	err = client.DeleteParameter(ctx, req)
 	if err != nil {
+		// A parameter that still has versions cannot be deleted.
+		if status.Code(err) == codes.FailedPrecondition {
+			return fmt.Errorf("parameter %s still has versions, delete its versions first: %w", parameterID, err)
+		}
 		return fmt.Errorf("failed to delete parameter: %w", err)
 	}
 
@@ -22,6 +22,8 @@ import (
 
 	parametermanager "cloud.google.com/go/parametermanager/apiv1"
 	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // deleteParamVersion deletes a parameter version using the Parameter Manager SDK for GCP.
@@ -31,7 +33,7 @@ import (
 // parameterID: The ID of the parameter for which the version is to be deleted.
 // versionID: The ID of the version to be deleted.
 //
-//	The function returns an error if the parameter version deletion fails.
+// The function returns an error if the parameter version deletion fails.
 func deleteParamVersion(w io.Writer, projectID, parameterID, versionID string) error {
 	// Create a new context.
 	ctx := context.Background()
@@ -53,6 +55,11 @@ func deleteParamVersion(w io.Writer, projectID, parameterID, versionID string) e
 
 	// Call the API to delete the parameter version.
 	if err := client.DeleteParameterVersion(ctx, req); err != nil {
+		// A version that does not exist has nothing left to delete.
+		if status.Code(err) == codes.NotFound {
+			fmt.Fprintf(w, "Parameter version %s not found, nothing to delete\n", name)
+			return nil
+		}
 		return fmt.Errorf("failed to delete parameter version: %w", err)
 	}
 
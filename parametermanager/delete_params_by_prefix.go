@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_delete_params_by_prefix]
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// deleteParamsByPrefix deletes every parameter whose ID starts with a prefix, along with
+// its versions, using the Parameter Manager SDK for GCP.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+// prefix: The prefix of the IDs of the parameters to be deleted.
+// dryRun: Whether to only print the parameters that would be deleted.
+//
+// The function returns an error if listing or deleting the parameters or their versions fails.
+func deleteParamsByPrefix(w io.Writer, projectID, prefix string, dryRun bool) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Collect the matching parameters before deleting any of them.
+	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
+	var names []string
+	parameters := client.ListParameters(ctx, &parametermanagerpb.ListParametersRequest{
+		Parent: parent,
+	})
+	for {
+		parameter, err := parameters.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameters: %w", err)
+		}
+		if strings.HasPrefix(path.Base(parameter.Name), prefix) {
+			names = append(names, parameter.Name)
+		}
+	}
+
+	if dryRun {
+		for _, name := range names {
+			fmt.Fprintf(w, "Would delete parameter %s\n", name)
+		}
+		fmt.Fprintf(w, "Would delete %d parameters\n", len(names))
+		return nil
+	}
+
+	for _, name := range names {
+		// A parameter can only be deleted once all of its versions are gone. Collect the
+		// version names before deleting, so the listing is not disturbed.
+		var versionNames []string
+		versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+			Parent: name,
+		})
+		for {
+			version, err := versions.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to list parameter versions of %s: %w", name, err)
+			}
+			versionNames = append(versionNames, version.Name)
+		}
+
+		for _, versionName := range versionNames {
+			if err := client.DeleteParameterVersion(ctx, &parametermanagerpb.DeleteParameterVersionRequest{
+				Name: versionName,
+			}); err != nil {
+				return fmt.Errorf("failed to delete parameter version %s: %w", versionName, err)
+			}
+		}
+
+		if err := client.DeleteParameter(ctx, &parametermanagerpb.DeleteParameterRequest{
+			Name: name,
+		}); err != nil {
+			return fmt.Errorf("failed to delete parameter %s: %w", name, err)
+		}
+		fmt.Fprintf(w, "Deleted parameter %s\n", name)
+	}
+
+	fmt.Fprintf(w, "Deleted %d parameters\n", len(names))
+	return nil
+}
+
+// [END parametermanager_delete_params_by_prefix]
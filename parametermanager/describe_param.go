@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_describe_param]
+import (
+	"context"
+	"fmt"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// ParamDescription summarizes a parameter for describeParam.
+type ParamDescription struct {
+	// Name is the full resource name of the parameter.
+	Name string
+	// Format is the payload format of the parameter, such as "JSON".
+	Format string
+	// KmsKey is the Cloud KMS key protecting the parameter; it is empty for a
+	// Google-managed key.
+	KmsKey string
+	// Labels are the labels attached to the parameter.
+	Labels map[string]string
+	// CreateTime is when the parameter was created.
+	CreateTime time.Time
+	// UpdateTime is when the parameter was last updated. It is the zero time.Time if
+	// the parameter has never been updated.
+	UpdateTime time.Time
+	// VersionCount is the number of versions of the parameter.
+	VersionCount int
+}
+
+// describeParam returns the metadata of a parameter and its number of versions using the
+// Parameter Manager SDK for GCP, for callers that want the values rather than printed
+// output.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be described.
+//
+// The function returns an error if the parameter retrieval or the version listing fails.
+func describeParam(ctx context.Context, projectID, parameterID string) (ParamDescription, error) {
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return ParamDescription{}, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return describeParamWithClient(ctx, client, projectID, parameterID)
+}
+
+// describeParamWithClient gets the parameter through client and lists its versions to
+// count them.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be described.
+//
+// The function returns an error if the parameter retrieval or the version listing fails.
+func describeParamWithClient(ctx context.Context, client ParameterClient, projectID, parameterID string) (ParamDescription, error) {
+	// Construct the name of the parameter to describe.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Call the API to get the parameter.
+	param, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return ParamDescription{}, fmt.Errorf("failed to get parameter: %w", err)
+	}
+
+	desc := ParamDescription{
+		Name:       param.Name,
+		Format:     param.Format.String(),
+		KmsKey:     param.GetKmsKey(),
+		Labels:     param.Labels,
+		CreateTime: param.GetCreateTime().AsTime(),
+	}
+	// A missing timestamp would convert to the Unix epoch, so leave it as the zero time.
+	if param.GetUpdateTime() != nil {
+		desc.UpdateTime = param.GetUpdateTime().AsTime()
+	}
+
+	// Count the versions; the list does not report a total, so every page is read.
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: name,
+	})
+	for {
+		_, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return ParamDescription{}, fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		desc.VersionCount++
+	}
+	return desc, nil
+}
+
+// [END parametermanager_describe_param]
@@ -0,0 +1,151 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_detect_drift]
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// detectSchemaDrift compares the top-level keys of every enabled version of a JSON
+// parameter with those of the newest enabled version, using the Parameter Manager SDK
+// for GCP, and prints each version whose keys differ. Keys the newest version has and an
+// older version lacks are reported as missing, and keys only the older version has as
+// extra, which points out fields that were dropped or renamed by accident.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the JSON parameter whose versions are to be compared.
+//
+// The function returns an error if the parameter is not a JSON parameter, listing or
+// rendering the versions fails, or a rendered payload is not a JSON object.
+func detectSchemaDrift(w io.Writer, projectID, parameterID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return detectSchemaDriftWithClient(ctx, client, w, projectID, parameterID)
+}
+
+// detectSchemaDriftWithClient renders the enabled versions of the parameter through
+// client and compares the top-level keys of each with those of the newest one.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the JSON parameter whose versions are to be compared.
+//
+// The function returns an error if the parameter is not a JSON parameter, listing or
+// rendering the versions fails, or a rendered payload is not a JSON object.
+func detectSchemaDriftWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID string) error {
+	// Check the parameter holds JSON.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter: %w", err)
+	}
+	if parameter.Format != parametermanagerpb.ParameterFormat_JSON {
+		return fmt.Errorf("parameter %s has format %s: only JSON parameters can be compared", name, parameter.Format)
+	}
+
+	// Call the API to list the enabled versions, newest first.
+	var enabled []*parametermanagerpb.ParameterVersion
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: name,
+	})
+	for {
+		version, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		if !version.Disabled {
+			enabled = append(enabled, version)
+		}
+	}
+	if len(enabled) == 0 {
+		fmt.Fprintf(w, "Parameter %s has no enabled versions\n", name)
+		return nil
+	}
+	sort.SliceStable(enabled, func(i, j int) bool {
+		return enabled[i].GetCreateTime().AsTime().After(enabled[j].GetCreateTime().AsTime())
+	})
+
+	// Render each version and collect its top-level keys.
+	keys := make([]map[string]interface{}, len(enabled))
+	for i, version := range enabled {
+		rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+			Name: version.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to render parameter version %s: %w", version.Name, err)
+		}
+		if err := json.Unmarshal(rendered.RenderedPayload, &keys[i]); err != nil || keys[i] == nil {
+			return fmt.Errorf("rendered payload of %s is not a JSON object", version.Name)
+		}
+	}
+
+	newest := enabled[0]
+	drifted := 0
+	for i, version := range enabled[1:] {
+		var missing, extra []string
+		for _, key := range slices.Sorted(maps.Keys(keys[0])) {
+			if _, ok := keys[i+1][key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(keys[i+1])) {
+			if _, ok := keys[0][key]; !ok {
+				extra = append(extra, key)
+			}
+		}
+		if len(missing) == 0 && len(extra) == 0 {
+			continue
+		}
+
+		drifted++
+		fmt.Fprintf(w, "Parameter version %s has different keys than newest version %s\n", version.Name, newest.Name)
+		if len(missing) > 0 {
+			fmt.Fprintf(w, "  missing: %s\n", strings.Join(missing, ", "))
+		}
+		if len(extra) > 0 {
+			fmt.Fprintf(w, "  extra: %s\n", strings.Join(extra, ", "))
+		}
+	}
+	fmt.Fprintf(w, "Found %d of %d enabled parameter versions with different keys than %s\n", drifted, len(enabled)-1, newest.Name)
+	return nil
+}
+
+// [END parametermanager_detect_drift]
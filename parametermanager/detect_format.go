@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_create_param_auto_format]
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"gopkg.in/yaml.v3"
+)
+
+// detectFormat returns the parameter format that best fits payload: JSON if it is valid
+// JSON, YAML if it is a single YAML document holding a mapping or a sequence, and
+// UNFORMATTED otherwise. Plain text is itself a valid YAML scalar, so scalar documents
+// are treated as UNFORMATTED.
+func detectFormat(payload []byte) parametermanagerpb.ParameterFormat {
+	if json.Valid(payload) {
+		return parametermanagerpb.ParameterFormat_JSON
+	}
+
+	// Decode the first YAML document. yaml.Unmarshal would silently ignore any documents
+	// after it, so use a decoder and check that no second document follows.
+	dec := yaml.NewDecoder(bytes.NewReader(payload))
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return parametermanagerpb.ParameterFormat_UNFORMATTED
+	}
+	if err := dec.Decode(new(interface{})); !errors.Is(err, io.EOF) {
+		return parametermanagerpb.ParameterFormat_UNFORMATTED
+	}
+
+	switch doc.(type) {
+	case map[string]interface{}, []interface{}:
+		return parametermanagerpb.ParameterFormat_YAML
+	default:
+		return parametermanagerpb.ParameterFormat_UNFORMATTED
+	}
+}
+
+// createParamAutoFormat creates a new parameter whose format is detected from a sample
+// payload using the Parameter Manager SDK for GCP.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be created.
+// payload: The sample data used to pick the parameter format.
+//
+// The function returns an error if the parameter creation fails.
+func createParamAutoFormat(w io.Writer, projectID, parameterID string, payload []byte) error {
+	// Pick the format from the payload.
+	format := detectFormat(payload)
+
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Build the request to create the parameter with the detected format.
+	req := &parametermanagerpb.CreateParameterRequest{
+		Parent:      fmt.Sprintf("projects/%s/locations/global", projectID),
+		ParameterId: parameterID,
+		Parameter: &parametermanagerpb.Parameter{
+			Format: format,
+		},
+	}
+
+	// Call the API to create the parameter.
+	parameter, err := client.CreateParameter(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create parameter: %w", err)
+	}
+
+	fmt.Fprintf(w, "Created parameter %s with detected format %s\n", parameter.Name, parameter.Format)
+	return nil
+}
+
+// [END parametermanager_create_param_auto_format]
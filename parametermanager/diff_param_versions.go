@@ -0,0 +1,184 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_diff_param_versions]
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// diffParamVersions prints a line-based diff between the stored payloads of two
+// parameter versions using the Parameter Manager SDK for GCP.
+//
+// For JSON parameters both payloads are re-encoded with sorted keys and
+// indentation first, so reordering keys does not show up as a change. The diff is
+// printed in unified format, with up to three unchanged lines of context around
+// each change.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose versions are to be compared.
+// versionA: The ID of the version to diff from.
+// versionB: The ID of the version to diff to.
+//
+// The function returns an error if the parameter or either version cannot be
+// retrieved, or if a JSON payload cannot be parsed.
+func diffParamVersions(w io.Writer, projectID, parameterID, versionA, versionB string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Get the parameter to find out whether its payloads are JSON.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter: %w", err)
+	}
+
+	// Fetch and normalize the stored payload of both versions.
+	var lines [2][]string
+	for i, versionID := range []string{versionA, versionB} {
+		version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+			Name: fmt.Sprintf("%s/versions/%s", name, versionID),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get parameter version %s: %w", versionID, err)
+		}
+
+		payload := version.GetPayload().GetData()
+		if parameter.Format == parametermanagerpb.ParameterFormat_JSON {
+			var v any
+			if err := json.Unmarshal(payload, &v); err != nil {
+				return fmt.Errorf("failed to parse payload of version %s: %w", versionID, err)
+			}
+			if payload, err = json.MarshalIndent(v, "", "  "); err != nil {
+				return fmt.Errorf("failed to normalize payload of version %s: %w", versionID, err)
+			}
+		}
+		lines[i] = strings.Split(strings.TrimSuffix(string(payload), "\n"), "\n")
+	}
+
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", versionA, versionB)
+	for _, line := range diffLines(lines[0], lines[1]) {
+		fmt.Fprintln(w, line)
+	}
+	return nil
+}
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// diffLines returns a unified diff of a and b, without the file header lines, based on a
+// longest common subsequence of the two. Changes are grouped into "@@" hunks with up to
+// diffContext unchanged lines around them; the result is empty if a and b are equal.
+func diffLines(a, b []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	// Build the full edit script, recording the position in a and b before each line.
+	type edit struct {
+		line   string
+		ai, bi int
+	}
+	var edits []edit
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, edit{" " + a[i], i, j})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{"-" + a[i], i, j})
+			i++
+		default:
+			edits = append(edits, edit{"+" + b[j], i, j})
+			j++
+		}
+	}
+
+	// Group the changes into hunks, merging changes whose context would overlap.
+	var out []string
+	for k := 0; k < len(edits); {
+		if edits[k].line[0] == ' ' {
+			k++
+			continue
+		}
+		first := max(0, k-diffContext)
+		last := k
+		for n := k + 1; n < len(edits) && n <= last+2*diffContext; n++ {
+			if edits[n].line[0] != ' ' {
+				last = n
+			}
+		}
+		end := min(len(edits), last+diffContext+1)
+
+		var aCount, bCount int
+		for _, e := range edits[first:end] {
+			if e.line[0] != '+' {
+				aCount++
+			}
+			if e.line[0] != '-' {
+				bCount++
+			}
+		}
+		out = append(out, fmt.Sprintf("@@ -%s +%s @@", hunkRange(edits[first].ai, aCount), hunkRange(edits[first].bi, bCount)))
+		for _, e := range edits[first:end] {
+			out = append(out, e.line)
+		}
+		k = end
+	}
+	return out
+}
+
+// hunkRange formats the line range of a hunk that starts after the first start lines and
+// spans count lines, as used in unified diff hunk headers.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
+
+// [END parametermanager_diff_param_versions]
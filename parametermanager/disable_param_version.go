@@ -25,7 +25,8 @@ import (
 	"google.golang.org/genproto/protobuf/field_mask"
 )
 
-// disableParamVersion disables a parameter version.
+// disableParamVersion disables a parameter version. Disabling a version that is
+// already disabled succeeds and leaves it disabled.
 //
 // w: The io.Writer object used to write the output.
 // projectID: The ID of the project where the parameter is located.
@@ -57,11 +58,13 @@ func disableParamVersion(w io.Writer, projectID, parameterID, versionID string)
 	}
 
 	// Call the API to disable the parameter version.
-	if _, err := client.UpdateParameterVersion(ctx, req); err != nil {
+	version, err := client.UpdateParameterVersion(ctx, req)
+	if err != nil {
 		return fmt.Errorf("failed to disable parameter version: %w", err)
 	}
 
-	fmt.Fprintf(w, "Disabled parameter version %s for parameter %s\n", name, parameterID)
+	fmt.Fprintf(w, "Disabled parameter version %s for parameter %s\n", version.Name, parameterID)
+	fmt.Fprintf(w, "Disabled state: %v\n", version.Disabled)
 	return nil
 }
 
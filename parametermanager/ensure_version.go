@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_ensure_version]
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errVersionConflict is returned by ensureVersion when the version already exists with a
+// different payload.
+var errVersionConflict = errors.New("parameter version already exists with a different payload")
+
+// ensureVersion creates a parameter version, or returns the existing version if one with
+// the same ID and payload is already present, so it is safe to call repeatedly. Versions
+// are immutable, so an existing version with another payload is reported as a conflict
+// rather than silently left in place.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The payload the version must hold.
+//
+// The function returns the version and whether it was created by this call, or an error
+// wrapping errVersionConflict if the existing payload differs, or if neither the creation
+// nor the retrieval succeeds.
+func ensureVersion(ctx context.Context, projectID, parameterID, versionID string, payload []byte) (*parametermanagerpb.ParameterVersion, bool, error) {
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return ensureVersionWithClient(ctx, client, projectID, parameterID, versionID, payload)
+}
+
+// ensureVersionWithClient tries to create the version through client and, if it already
+// exists, gets it and compares its payload with payload.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The payload the version must hold.
+//
+// The function returns the version and whether it was created by this call, or an error
+// wrapping errVersionConflict if the existing payload differs, or if neither the creation
+// nor the retrieval succeeds.
+func ensureVersionWithClient(ctx context.Context, client ParameterClient, projectID, parameterID, versionID string, payload []byte) (*parametermanagerpb.ParameterVersion, bool, error) {
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Call the API to create the parameter version.
+	version, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err == nil {
+		return version, true, nil
+	}
+	if status.Code(err) != codes.AlreadyExists {
+		return nil, false, fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	// The version already exists, so fetch it and compare the payloads.
+	version, err = client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: fmt.Sprintf("%s/versions/%s", parent, versionID),
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get existing parameter version: %w", err)
+	}
+	if !bytes.Equal(version.GetPayload().GetData(), payload) {
+		return nil, false, fmt.Errorf("%s: %w", version.Name, errVersionConflict)
+	}
+	return version, false, nil
+}
+
+// [END parametermanager_ensure_version]
@@ -0,0 +1,41 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// classifyError returns the gRPC code of err together with a short hint on how to
+// resolve the most common Parameter Manager failures. Errors that wrap a gRPC
+// status are unwrapped; any other non-nil error is reported as codes.Unknown.
+func classifyError(err error) (code codes.Code, hint string) {
+	code = status.Code(err)
+	switch code {
+	case codes.OK:
+		return code, ""
+	case codes.NotFound:
+		return code, "check the resource ID"
+	case codes.PermissionDenied:
+		return code, "verify IAM roles and the service agent's KMS access"
+	case codes.FailedPrecondition:
+		return code, "delete child versions first"
+	case codes.AlreadyExists:
+		return code, "resource exists; use get or update"
+	default:
+		return code, "see the error details for more information"
+	}
+}
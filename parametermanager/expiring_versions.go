@@ -0,0 +1,257 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_expiring_versions]
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expiresAtLabelPrefix starts the parameter label that holds the expiry of a version. The
+// version ID follows the prefix, and the label value is the expiry in Unix seconds.
+const expiresAtLabelPrefix = "expires_at-"
+
+// createExpiringVersion creates a parameter version that reapExpiredVersions disables once
+// ttl has passed, using the Parameter Manager SDK for GCP.
+//
+// Parameter Manager has no expiry for versions, and versions have no labels, so the
+// expiry is stored as an "expires_at-<versionID>" label on the parameter. Label values
+// cannot hold a timestamp, so the expiry is stored in Unix seconds, rounded up. A
+// parameter can have at most 64 labels, and the version ID must be valid in a label
+// key: lowercase letters, digits, underscores and dashes.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The payload of the version.
+// ttl: How long the version stays enabled.
+//
+// The function returns an error if the version ID cannot be used in a label, or if the
+// parameter version creation or the label update fails.
+func createExpiringVersion(w io.Writer, projectID, parameterID, versionID string, payload []byte, ttl time.Duration) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return createExpiringVersionWithClient(ctx, client, w, projectID, parameterID, versionID, payload, ttl)
+}
+
+// createExpiringVersionWithClient creates the version through client and then adds its
+// expires_at label to the parameter, with the expiry counted from the current time.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// payload: The payload of the version.
+// ttl: How long the version stays enabled.
+//
+// The function returns an error if the version ID cannot be used in a label, or if the
+// parameter version creation or the label update fails.
+func createExpiringVersionWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, versionID string, payload []byte, ttl time.Duration) error {
+	expires := time.Now().Add(ttl)
+	seconds := expires.Unix()
+	if expires.Nanosecond() > 0 {
+		seconds++
+	}
+	// The label key must be a valid GCP label key; the value is digits only.
+	key := expiresAtLabelPrefix + versionID
+	if !regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`).MatchString(key) {
+		return fmt.Errorf("invalid label key %q: must start with a lowercase letter and contain at most 63 lowercase letters, digits, underscores, or dashes", key)
+	}
+	label := map[string]string{key: strconv.FormatInt(seconds, 10)}
+
+	// Call the API to create the parameter version. It is created before the label is
+	// set, so an existing version with the same ID never gets an expiry.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	version, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             name,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	// Add the expiry to the labels already on the parameter.
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("created %s but failed to get parameter to set its expiry: %w", version.Name, err)
+	}
+	labels := maps.Clone(parameter.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	maps.Copy(labels, label)
+	if _, err := client.UpdateParameter(ctx, &parametermanagerpb.UpdateParameterRequest{
+		Parameter: &parametermanagerpb.Parameter{
+			Name:   name,
+			Labels: labels,
+		},
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"labels"},
+		},
+	}); err != nil {
+		return fmt.Errorf("created %s but failed to set its expiry: %w", version.Name, err)
+	}
+
+	fmt.Fprintf(w, "Created parameter version %s expiring at %s\n", version.Name, time.Unix(seconds, 0).UTC().Format(time.RFC3339))
+	return nil
+}
+
+// reapExpiredVersions disables the versions of a parameter whose expiry, set by
+// createExpiringVersion, has passed, using the Parameter Manager SDK for GCP. The
+// expiry labels of reaped versions, and of versions that no longer exist, are then
+// removed from the parameter. Run it on a schedule to enforce the expiries.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose versions are to be reaped.
+// dryRun: Whether to only print the versions that would be disabled.
+//
+// The function returns an error if the parameter retrieval, a version update, or the
+// label update fails.
+func reapExpiredVersions(w io.Writer, projectID, parameterID string, dryRun bool) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return reapExpiredVersionsWithClient(ctx, client, w, projectID, parameterID, time.Now(), dryRun)
+}
+
+// reapExpiredVersionsWithClient disables, through client, the versions whose expiry is
+// not after now. Taking now as an argument lets a caller reap as of a fixed time.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose versions are to be reaped.
+// now: The time the expiries are compared with.
+// dryRun: Whether to only print the versions that would be disabled.
+//
+// The function returns an error if the parameter retrieval, a version update, or the
+// label update fails.
+func reapExpiredVersionsWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID string, now time.Time, dryRun bool) error {
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter: %w", err)
+	}
+
+	labels := maps.Clone(parameter.Labels)
+	reaped := 0
+	for _, key := range slices.Sorted(maps.Keys(parameter.Labels)) {
+		versionID, ok := strings.CutPrefix(key, expiresAtLabelPrefix)
+		if !ok {
+			continue
+		}
+		seconds, err := strconv.ParseInt(parameter.Labels[key], 10, 64)
+		if err != nil {
+			fmt.Fprintf(w, "Skipping label %s: invalid expiry %q\n", key, parameter.Labels[key])
+			continue
+		}
+		expires := time.Unix(seconds, 0)
+		if now.Before(expires) {
+			continue
+		}
+
+		versionName := fmt.Sprintf("%s/versions/%s", name, versionID)
+		expiresAt := expires.UTC().Format(time.RFC3339)
+		if dryRun {
+			fmt.Fprintf(w, "Would disable parameter version %s, which expired at %s\n", versionName, expiresAt)
+			reaped++
+			continue
+		}
+
+		// Call the API to disable the expired version.
+		_, err = client.UpdateParameterVersion(ctx, &parametermanagerpb.UpdateParameterVersionRequest{
+			ParameterVersion: &parametermanagerpb.ParameterVersion{
+				Name:     versionName,
+				Disabled: true,
+			},
+			UpdateMask: &field_mask.FieldMask{
+				Paths: []string{"disabled"},
+			},
+		})
+		switch {
+		case status.Code(err) == codes.NotFound:
+			fmt.Fprintf(w, "Removing expiry of deleted parameter version %s\n", versionName)
+		case err != nil:
+			return fmt.Errorf("failed to disable parameter version %s: %w", versionName, err)
+		default:
+			fmt.Fprintf(w, "Disabled parameter version %s, which expired at %s\n", versionName, expiresAt)
+			reaped++
+		}
+		delete(labels, key)
+	}
+
+	if dryRun {
+		fmt.Fprintf(w, "Would disable %d expired parameter versions\n", reaped)
+		return nil
+	}
+
+	// Call the API to drop the labels of the versions that were handled.
+	if len(labels) != len(parameter.Labels) {
+		if _, err := client.UpdateParameter(ctx, &parametermanagerpb.UpdateParameterRequest{
+			Parameter: &parametermanagerpb.Parameter{
+				Name:   name,
+				Labels: labels,
+			},
+			UpdateMask: &field_mask.FieldMask{
+				Paths: []string{"labels"},
+			},
+		}); err != nil {
+			return fmt.Errorf("failed to remove expiry labels: %w", err)
+		}
+	}
+	fmt.Fprintf(w, "Disabled %d expired parameter versions\n", reaped)
+	return nil
+}
+
+// [END parametermanager_expiring_versions]
@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_export_param]
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// paramExport is the JSON document written by exportParam and read by importParam.
+type paramExport struct {
+	// Format is the format of the exported parameter, e.g. "JSON".
+	Format string `json:"format"`
+	// Versions maps each version ID to its stored payload and state.
+	Versions map[string]versionExport `json:"versions"`
+}
+
+// versionExport is a single exported parameter version.
+type versionExport struct {
+	// Payload is the stored (unrendered) payload, encoded as base64 in JSON.
+	Payload []byte `json:"payload"`
+	// Disabled reports whether the version was disabled.
+	Disabled bool `json:"disabled"`
+}
+
+// exportParam exports all versions of a parameter to a local JSON file using the
+// Parameter Manager SDK for GCP.
+//
+// The stored payloads are exported, so secret references are kept as references
+// and no secret values are written to the file.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be exported.
+// outputPath: The path of the file to write the export to.
+//
+// The function returns an error if reading the parameter or its versions, or
+// writing the file, fails.
+func exportParam(w io.Writer, projectID, parameterID, outputPath string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter to export.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Get the parameter to record its format.
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter: %w", err)
+	}
+
+	export := paramExport{
+		Format:   parameter.Format.String(),
+		Versions: make(map[string]versionExport),
+	}
+
+	// List the versions and fetch each one's stored payload.
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: name,
+	})
+	for {
+		listed, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+
+		version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+			Name: listed.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get parameter version: %w", err)
+		}
+
+		export.Versions[path.Base(version.Name)] = versionExport{
+			Payload:  version.GetPayload().GetData(),
+			Disabled: version.Disabled,
+		}
+	}
+
+	// Write the export to the output file.
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode export: %w", err)
+	}
+	if err := os.WriteFile(outputPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	fmt.Fprintf(w, "Exported %d parameter versions of %s to %s\n", len(export.Versions), name, outputPath)
+	return nil
+}
+
+// [END parametermanager_export_param]
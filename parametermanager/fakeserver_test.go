@@ -0,0 +1,369 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeServerDefaultPageSize is the page size fakeParameterManagerServer uses when a
+// request does not set one.
+const fakeServerDefaultPageSize = 50
+
+// fakeParameterManagerServer is an in-process Parameter Manager server backed by memory.
+// Unlike fakeParameterClient, it is reached through a real *parametermanager.Client, so
+// the client's iterators and paging run unchanged. It implements the parameter and version
+// RPCs with the service's AlreadyExists, NotFound and FailedPrecondition errors, and
+// RenderParameterVersion returns the stored payload without resolving secret references.
+// ListParameters returns only the parameters under the request parent, and its page tokens
+// are the decimal offset of the next one of those; versions are not paged.
+type fakeParameterManagerServer struct {
+	parametermanagerpb.UnimplementedParameterManagerServer
+
+	mu         sync.Mutex
+	parameters []*parametermanagerpb.Parameter
+	// versions maps parameter names to their versions.
+	versions map[string][]*parametermanagerpb.ParameterVersion
+
+	listParametersCalls int
+	deleteCalls         int
+}
+
+// newFakeParameterManagerServer returns a fakeParameterManagerServer holding n UNFORMATTED
+// parameters in the global location of projectID.
+func newFakeParameterManagerServer(projectID string, n int) *fakeParameterManagerServer {
+	s := &fakeParameterManagerServer{versions: make(map[string][]*parametermanagerpb.ParameterVersion)}
+	for i := 0; i < n; i++ {
+		s.parameters = append(s.parameters, &parametermanagerpb.Parameter{
+			Name:   fmt.Sprintf("projects/%s/locations/global/parameters/parameter-%d", projectID, i),
+			Format: parametermanagerpb.ParameterFormat_UNFORMATTED,
+		})
+	}
+	return s
+}
+
+func (s *fakeParameterManagerServer) ListParameters(ctx context.Context, req *parametermanagerpb.ListParametersRequest) (*parametermanagerpb.ListParametersResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listParametersCalls++
+
+	// Only the parameters directly under req.Parent are listed, as the service does.
+	var parameters []*parametermanagerpb.Parameter
+	for _, p := range s.parameters {
+		if strings.HasPrefix(p.Name, req.Parent+"/parameters/") {
+			parameters = append(parameters, p)
+		}
+	}
+
+	offset := 0
+	if req.PageToken != "" {
+		var err error
+		if offset, err = strconv.Atoi(req.PageToken); err != nil || offset < 0 || offset > len(parameters) {
+			return nil, grpcstatus.Errorf(grpccodes.InvalidArgument, "invalid page token %q", req.PageToken)
+		}
+	}
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = fakeServerDefaultPageSize
+	}
+	end := min(offset+pageSize, len(parameters))
+
+	resp := &parametermanagerpb.ListParametersResponse{
+		Parameters: parameters[offset:end],
+	}
+	if end < len(parameters) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	return resp, nil
+}
+
+func (s *fakeParameterManagerServer) CreateParameter(ctx context.Context, req *parametermanagerpb.CreateParameterRequest) (*parametermanagerpb.Parameter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := fmt.Sprintf("%s/parameters/%s", req.Parent, req.ParameterId)
+	if s.parameter(name) >= 0 {
+		return nil, grpcstatus.Errorf(grpccodes.AlreadyExists, "parameter %s already exists", name)
+	}
+	parameter := proto.Clone(req.Parameter).(*parametermanagerpb.Parameter)
+	parameter.Name = name
+	parameter.CreateTime = timestamppb.Now()
+	parameter.UpdateTime = parameter.CreateTime
+	s.parameters = append(s.parameters, parameter)
+	return parameter, nil
+}
+
+func (s *fakeParameterManagerServer) GetParameter(ctx context.Context, req *parametermanagerpb.GetParameterRequest) (*parametermanagerpb.Parameter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.parameter(req.Name)
+	if i < 0 {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter %s not found", req.Name)
+	}
+	return s.parameters[i], nil
+}
+
+func (s *fakeParameterManagerServer) UpdateParameter(ctx context.Context, req *parametermanagerpb.UpdateParameterRequest) (*parametermanagerpb.Parameter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.parameter(req.Parameter.GetName())
+	if i < 0 {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter %s not found", req.Parameter.GetName())
+	}
+	parameter := proto.Clone(s.parameters[i]).(*parametermanagerpb.Parameter)
+	for _, path := range req.UpdateMask.GetPaths() {
+		switch path {
+		case "format":
+			parameter.Format = req.Parameter.Format
+		case "labels":
+			parameter.Labels = req.Parameter.Labels
+		case "kms_key":
+			parameter.KmsKey = req.Parameter.KmsKey
+		default:
+			return nil, grpcstatus.Errorf(grpccodes.InvalidArgument, "field %s cannot be updated", path)
+		}
+	}
+	parameter.UpdateTime = timestamppb.Now()
+	s.parameters[i] = parameter
+	return parameter, nil
+}
+
+func (s *fakeParameterManagerServer) CreateParameterVersion(ctx context.Context, req *parametermanagerpb.CreateParameterVersionRequest) (*parametermanagerpb.ParameterVersion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.parameter(req.Parent) < 0 {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter %s not found", req.Parent)
+	}
+	name := fmt.Sprintf("%s/versions/%s", req.Parent, req.ParameterVersionId)
+	if s.version(name) != nil {
+		return nil, grpcstatus.Errorf(grpccodes.AlreadyExists, "parameter version %s already exists", name)
+	}
+	version := proto.Clone(req.ParameterVersion).(*parametermanagerpb.ParameterVersion)
+	version.Name = name
+	version.CreateTime = timestamppb.Now()
+	version.UpdateTime = version.CreateTime
+	s.versions[req.Parent] = append(s.versions[req.Parent], version)
+	return version, nil
+}
+
+func (s *fakeParameterManagerServer) GetParameterVersion(ctx context.Context, req *parametermanagerpb.GetParameterVersionRequest) (*parametermanagerpb.ParameterVersion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	version := s.version(req.Name)
+	if version == nil {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter version %s not found", req.Name)
+	}
+	return version, nil
+}
+
+func (s *fakeParameterManagerServer) UpdateParameterVersion(ctx context.Context, req *parametermanagerpb.UpdateParameterVersionRequest) (*parametermanagerpb.ParameterVersion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := req.ParameterVersion.GetName()
+	stored := s.version(name)
+	if stored == nil {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter version %s not found", name)
+	}
+	// Stored messages are never modified, since responses may still be reading them.
+	version := proto.Clone(stored).(*parametermanagerpb.ParameterVersion)
+	for _, path := range req.UpdateMask.GetPaths() {
+		if path != "disabled" {
+			return nil, grpcstatus.Errorf(grpccodes.InvalidArgument, "field %s cannot be updated", path)
+		}
+		version.Disabled = req.ParameterVersion.Disabled
+	}
+	version.UpdateTime = timestamppb.Now()
+
+	parent, _, _ := strings.Cut(name, "/versions/")
+	versions := slices.Clone(s.versions[parent])
+	versions[slices.Index(versions, stored)] = version
+	s.versions[parent] = versions
+	return version, nil
+}
+
+func (s *fakeParameterManagerServer) RenderParameterVersion(ctx context.Context, req *parametermanagerpb.RenderParameterVersionRequest) (*parametermanagerpb.RenderParameterVersionResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	version := s.version(req.Name)
+	if version == nil {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter version %s not found", req.Name)
+	}
+	if version.Disabled {
+		return nil, grpcstatus.Errorf(grpccodes.FailedPrecondition, "parameter version %s is disabled", req.Name)
+	}
+	return &parametermanagerpb.RenderParameterVersionResponse{
+		ParameterVersion: version.Name,
+		Payload:          version.Payload,
+		RenderedPayload:  version.GetPayload().GetData(),
+	}, nil
+}
+
+func (s *fakeParameterManagerServer) ListParameterVersions(ctx context.Context, req *parametermanagerpb.ListParameterVersionsRequest) (*parametermanagerpb.ListParameterVersionsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.parameter(req.Parent) < 0 {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter %s not found", req.Parent)
+	}
+	return &parametermanagerpb.ListParameterVersionsResponse{
+		ParameterVersions: s.versions[req.Parent],
+	}, nil
+}
+
+func (s *fakeParameterManagerServer) DeleteParameterVersion(ctx context.Context, req *parametermanagerpb.DeleteParameterVersionRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleteCalls++
+
+	parent, _, _ := strings.Cut(req.Name, "/versions/")
+	versions := s.versions[parent]
+	for i, version := range versions {
+		if version.Name == req.Name {
+			s.versions[parent] = append(versions[:i:i], versions[i+1:]...)
+			return &emptypb.Empty{}, nil
+		}
+	}
+	return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter version %s not found", req.Name)
+}
+
+func (s *fakeParameterManagerServer) DeleteParameter(ctx context.Context, req *parametermanagerpb.DeleteParameterRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleteCalls++
+
+	i := s.parameter(req.Name)
+	if i < 0 {
+		return nil, grpcstatus.Errorf(grpccodes.NotFound, "parameter %s not found", req.Name)
+	}
+	if len(s.versions[req.Name]) > 0 {
+		return nil, grpcstatus.Errorf(grpccodes.FailedPrecondition, "parameter %s still has versions", req.Name)
+	}
+	s.parameters = append(s.parameters[:i:i], s.parameters[i+1:]...)
+	return &emptypb.Empty{}, nil
+}
+
+// parameter returns the index of the named parameter, or -1. s.mu must be held.
+func (s *fakeParameterManagerServer) parameter(name string) int {
+	for i, p := range s.parameters {
+		if p.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// version returns the named parameter version, or nil. s.mu must be held.
+func (s *fakeParameterManagerServer) version(name string) *parametermanagerpb.ParameterVersion {
+	parent, _, _ := strings.Cut(name, "/versions/")
+	for _, version := range s.versions[parent] {
+		if version.Name == name {
+			return version
+		}
+	}
+	return nil
+}
+
+// addVersions adds versions with the given IDs to the named parameter.
+func (s *fakeParameterManagerServer) addVersions(parameterName string, versionIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, versionID := range versionIDs {
+		s.versions[parameterName] = append(s.versions[parameterName], &parametermanagerpb.ParameterVersion{
+			Name: fmt.Sprintf("%s/versions/%s", parameterName, versionID),
+		})
+	}
+}
+
+// calls returns the number of ListParameters calls served so far.
+func (s *fakeParameterManagerServer) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listParametersCalls
+}
+
+// newFakeServerClient starts srv on an in-memory listener and returns a Parameter Manager
+// client connected to it. The server and the client are stopped when the test ends.
+func newFakeServerClient(tb testing.TB, srv parametermanagerpb.ParameterManagerServer) *parametermanager.Client {
+	tb.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	parametermanagerpb.RegisterParameterManagerServer(s, srv)
+	go s.Serve(lis)
+	tb.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		tb.Fatalf("failed to dial fake server: %v", err)
+	}
+
+	client, err := parametermanager.NewClient(context.Background(), option.WithGRPCConn(conn))
+	if err != nil {
+		tb.Fatalf("failed to create Parameter Manager client: %v", err)
+	}
+	tb.Cleanup(func() { client.Close() })
+	return client
+}
+
+// testProjectClient returns a project and a Parameter Manager client for tests that can run
+// with or without GCP access. If GOLANG_SAMPLES_PROJECT_ID is set, they are that project and
+// a client for the API; otherwise they are a placeholder project and a client for an empty
+// fakeParameterManagerServer.
+func testProjectClient(t *testing.T) (string, *parametermanager.Client) {
+	t.Helper()
+
+	projectID := os.Getenv("GOLANG_SAMPLES_PROJECT_ID")
+	if projectID == "" {
+		return "fake-project", newFakeServerClient(t, newFakeParameterManagerServer("fake-project", 0))
+	}
+
+	client, err := parametermanager.NewClient(context.Background())
+	if err != nil {
+		t.Fatalf("testProjectClient: failed to create client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return projectID, client
+}
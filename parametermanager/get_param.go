@@ -22,6 +22,8 @@ import (
 
 	parametermanager "cloud.google.com/go/parametermanager/apiv1"
 	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // getParam get parameter using the Parameter Manager SDK for GCP.
@@ -30,7 +32,7 @@ import (
 // projectID: The ID of the project where the parameter is located.
 // parameterID: The ID of the parameter to retrieved.
 //
-// The function returns an error if the parameter retrieval fails.
+// The function returns an error if the parameter does not exist or the retrieval fails.
 func getParam(w io.Writer, projectID, parameterID string) error {
 	// Create a context and a Parameter Manager client.
 	ctx := context.Background()
@@ -40,6 +42,20 @@ func getParam(w io.Writer, projectID, parameterID string) error {
 	}
 	defer client.Close()
 
+	return getParamWithClient(ctx, client, w, projectID, parameterID)
+}
+
+// getParamWithClient gets the parameter through client and prints its name, format and
+// encryption key.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to retrieved.
+//
+// The function returns an error if the parameter does not exist or the retrieval fails.
+func getParamWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID string) error {
 	// Construct the name of the parameter to get parameter.
 	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
 
@@ -51,12 +67,20 @@ func getParam(w io.Writer, projectID, parameterID string) error {
 	// Call the API to get parameter.
 	param, err := client.GetParameter(ctx, req)
 	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("parameter %s not found", parameterID)
+		}
 		return fmt.Errorf("failed to get parameter: %w", err)
 	}
 
 	// Find more details for the Parameter object here:
 	// https://cloud.google.com/secret-manager/parameter-manager/docs/reference/rest/v1/projects.locations.parameters#Parameter
 	fmt.Fprintf(w, "Found parameter %s with format %s\n", param.Name, param.Format.String())
+	if param.KmsKey != nil && *param.KmsKey != "" {
+		fmt.Fprintf(w, "Parameter is encrypted with kms_key %s\n", *param.KmsKey)
+	} else {
+		fmt.Fprintf(w, "Parameter is encrypted with a Google-managed key\n")
+	}
 	return nil
 }
 
@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_get_param_timestamps]
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// getParamTimestamps prints when a parameter was created and last updated using the
+// Parameter Manager SDK for GCP. Times are printed in RFC 3339 format in UTC, and
+// "never" is printed if the parameter has no update time.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose timestamps are to be retrieved.
+//
+// The function returns an error if the parameter retrieval fails.
+func getParamTimestamps(w io.Writer, projectID, parameterID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter to get.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Call the API to get the parameter.
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter: %w", err)
+	}
+
+	updateTime := "never"
+	if parameter.UpdateTime != nil {
+		updateTime = parameter.UpdateTime.AsTime().UTC().Format(time.RFC3339)
+	}
+
+	fmt.Fprintf(w, "Parameter %s\n", parameter.Name)
+	fmt.Fprintf(w, "Created: %s\n", parameter.GetCreateTime().AsTime().UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "Updated: %s\n", updateTime)
+	return nil
+}
+
+// [END parametermanager_get_param_timestamps]
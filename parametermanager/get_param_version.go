@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	parametermanager "cloud.google.com/go/parametermanager/apiv1"
 	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
@@ -26,6 +27,10 @@ import (
 
 // getParamVersion get parameter version using the Parameter Manager SDK for GCP.
 //
+// GetParameterVersion returns the payload exactly as it was stored. Unlike
+// RenderParameterVersion, any secret references in the payload are returned
+// unresolved.
+//
 // w: The io.Writer object used to write the output.
 // projectID: The ID of the project where the parameter is located.
 // parameterID: The ID of the parameter for which the version details are to be retrieved.
@@ -41,6 +46,10 @@ func getParamVersion(w io.Writer, projectID, parameterID, versionID string) erro
 	}
 	defer client.Close()
 
+	// Bound the call to 30 seconds, so it cannot hang.
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
 	// Construct the name of the parameter to get the parameter version.
 	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
 
@@ -58,6 +67,7 @@ func getParamVersion(w io.Writer, projectID, parameterID, versionID string) erro
 	// Find more details for the Parameter Version object here:
 	// https://cloud.google.com/secret-manager/parameter-manager/docs/reference/rest/v1/projects.locations.parameters.versions#ParameterVersion
 	fmt.Fprintf(w, "Found parameter version %s with disabled state in %v\n", version.Name, version.Disabled)
+	fmt.Fprintf(w, "Payload length: %d bytes\n", len(version.GetPayload().GetData()))
 	if !version.Disabled {
 		fmt.Fprintf(w, "Payload: %s\n", version.Payload.Data)
 	}
@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_grant_render_access]
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"cloud.google.com/go/iam"
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+)
+
+// grantRenderAccess grants a service account access to a Secret Manager secret so that
+// parameter versions referencing the secret can be rendered.
+//
+// Rendering fails with PermissionDenied when the caller cannot read a referenced
+// secret, so the service account is added to roles/secretmanager.secretAccessor on
+// the secret's IAM policy.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the secret is located.
+// secretID: The ID of the secret referenced by the parameter version.
+// serviceAccount: The email of the service account, or a full IAM member such as "serviceAccount:sa@example.com".
+//
+// The function returns an error if reading or updating the secret's IAM policy fails.
+func grantRenderAccess(w io.Writer, projectID, secretID, serviceAccount string) error {
+	// Create a context and a Secret Manager client.
+	ctx := context.Background()
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Secret Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the secret and the IAM member to grant.
+	name := fmt.Sprintf("projects/%s/secrets/%s", projectID, secretID)
+	member := serviceAccount
+	if !strings.Contains(member, ":") {
+		member = "serviceAccount:" + member
+	}
+
+	// Get the current IAM policy of the secret.
+	handle := client.IAM(name)
+	policy, err := handle.Policy(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get IAM policy: %w", err)
+	}
+
+	// Grant the member access to the secret's payload.
+	role := iam.RoleName("roles/secretmanager.secretAccessor")
+	policy.Add(member, role)
+	if err := handle.SetPolicy(ctx, policy); err != nil {
+		return fmt.Errorf("failed to set IAM policy: %w", err)
+	}
+
+	fmt.Fprintf(w, "Updated IAM policy for %s\n", name)
+	fmt.Fprintf(w, "Binding %s: %s\n", role, strings.Join(policy.Members(role), ", "))
+	return nil
+}
+
+// [END parametermanager_grant_render_access]
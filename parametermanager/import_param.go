@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_import_param]
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// importParam imports parameter versions from a JSON file written by exportParam
+// using the Parameter Manager SDK for GCP.
+//
+// The parameter is created with the exported format if it does not exist yet.
+// Versions whose IDs already exist are skipped.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is to be imported.
+// parameterID: The ID of the parameter to import the versions into.
+// inputPath: The path of the export file to read.
+//
+// The function returns an error if the file cannot be read or the parameter or
+// version creation fails.
+func importParam(w io.Writer, projectID, parameterID, inputPath string) error {
+	// Read and decode the export file.
+	data, err := os.ReadFile(inputPath)
+	if err != nil {
+		return fmt.Errorf("failed to read export file: %w", err)
+	}
+	// The export layout written by exportParam.
+	var export struct {
+		Format   string `json:"format"`
+		Versions map[string]struct {
+			Payload  []byte `json:"payload"`
+			Disabled bool   `json:"disabled"`
+		} `json:"versions"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("failed to decode export file: %w", err)
+	}
+	format, ok := parametermanagerpb.ParameterFormat_value[export.Format]
+	if !ok {
+		return fmt.Errorf("invalid parameter format %q in export file", export.Format)
+	}
+
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Create the parameter, tolerating one that already exists.
+	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
+	name := fmt.Sprintf("%s/parameters/%s", parent, parameterID)
+	_, err = client.CreateParameter(ctx, &parametermanagerpb.CreateParameterRequest{
+		Parent:      parent,
+		ParameterId: parameterID,
+		Parameter: &parametermanagerpb.Parameter{
+			Format: parametermanagerpb.ParameterFormat(format),
+		},
+	})
+	if err != nil && status.Code(err) != codes.AlreadyExists {
+		return fmt.Errorf("failed to create parameter: %w", err)
+	}
+
+	// Recreate the versions in a stable order.
+	versionIDs := make([]string, 0, len(export.Versions))
+	for versionID := range export.Versions {
+		versionIDs = append(versionIDs, versionID)
+	}
+	sort.Strings(versionIDs)
+
+	imported := 0
+	for _, versionID := range versionIDs {
+		version := export.Versions[versionID]
+		_, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+			Parent:             name,
+			ParameterVersionId: versionID,
+			ParameterVersion: &parametermanagerpb.ParameterVersion{
+				Disabled: version.Disabled,
+				Payload: &parametermanagerpb.ParameterVersionPayload{
+					Data: version.Payload,
+				},
+			},
+		})
+		if status.Code(err) == codes.AlreadyExists {
+			fmt.Fprintf(w, "Parameter version %s already exists, skipping\n", versionID)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create parameter version %s: %w", versionID, err)
+		}
+		imported++
+	}
+
+	fmt.Fprintf(w, "Imported %d parameter versions into %s\n", imported, name)
+	return nil
+}
+
+// [END parametermanager_import_param]
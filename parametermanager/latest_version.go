@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_get_latest_enabled_version]
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// errNoEnabledVersions is returned by getLatestEnabledVersion when every version of the
+// parameter is disabled, or the parameter has none.
+var errNoEnabledVersions = errors.New("no enabled versions")
+
+// getLatestEnabledVersion returns the most recently created enabled version of a parameter
+// using the Parameter Manager SDK for GCP.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used to list the versions.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose versions are to be searched.
+//
+// The function returns an error if listing the versions fails or if the parameter
+// has no enabled versions, in which case the error wraps errNoEnabledVersions.
+func getLatestEnabledVersion(ctx context.Context, client ParameterClient, projectID, parameterID string) (*parametermanagerpb.ParameterVersion, error) {
+	// Construct the name of the parameter whose versions are listed.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Keep the enabled version with the most recent creation time.
+	var latest *parametermanagerpb.ParameterVersion
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: parent,
+	})
+	for {
+		version, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		if version.Disabled {
+			continue
+		}
+		if latest == nil || version.GetCreateTime().AsTime().After(latest.GetCreateTime().AsTime()) {
+			latest = version
+		}
+	}
+
+	if latest == nil {
+		return nil, fmt.Errorf("parameter %s has %w", parent, errNoEnabledVersions)
+	}
+	return latest, nil
+}
+
+// [END parametermanager_get_latest_enabled_version]
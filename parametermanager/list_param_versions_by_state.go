@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_list_param_versions_by_state]
+import (
+	"context"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// listParamVersionsByState lists the parameter versions that are either enabled or disabled
+// using the Parameter Manager SDK for GCP.
+//
+// The versions are filtered client-side: every version is listed and only those
+// whose Disabled flag matches are printed, rather than relying on a server-side
+// filter on the disabled state.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the versions are to be listed.
+// disabled: Whether to list the disabled versions instead of the enabled ones.
+//
+// The function returns an error if the parameter version listing fails.
+func listParamVersionsByState(w io.Writer, projectID, parameterID string, disabled bool) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the list parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Build the request to list parameter versions.
+	req := &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: parent,
+	}
+
+	// Call the API to list parameter versions and keep those in the requested state.
+	count := 0
+	parameterVersions := client.ListParameterVersions(ctx, req)
+	for {
+		version, err := parameterVersions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		if version.Disabled != disabled {
+			continue
+		}
+
+		fmt.Fprintf(w, "Found parameter version %s\n", version.Name)
+		count++
+	}
+
+	state := "enabled"
+	if disabled {
+		state = "disabled"
+	}
+	fmt.Fprintf(w, "Found %d %s parameter versions\n", count, state)
+	return nil
+}
+
+// [END parametermanager_list_param_versions_by_state]
@@ -25,12 +25,14 @@ import (
 	"google.golang.org/api/iterator"
 )
 
-// listParam lists parameters using the Parameter Manager SDK for GCP.
+// listParams lists all parameters in a project using the Parameter Manager SDK for GCP.
+// The returned iterator fetches additional pages from the API as needed, so
+// draining it until iterator.Done visits every parameter, not just the first page.
 //
 // w: The io.Writer object used to write the output.
 // projectID: The ID of the project where the parameters are located.
 //
-// The function returns an error if the parameter listing fails.
+// The function returns an error if the parameter listing fails or the context is cancelled.
 func listParams(w io.Writer, projectID string) error {
 	// Create a context and a Parameter Manager client.
 	ctx := context.Background()
@@ -40,6 +42,19 @@ func listParams(w io.Writer, projectID string) error {
 	}
 	defer client.Close()
 
+	return listParamsWithClient(ctx, client, w, projectID)
+}
+
+// listParamsWithClient drains the parameter iterator of client and prints each
+// parameter, across every page.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+//
+// The function returns an error if the parameter listing fails or the context is cancelled.
+func listParamsWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID string) error {
 	// Construct the name of the list parameter.
 	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
 	// Build the request to list parameters.
@@ -48,8 +63,13 @@ func listParams(w io.Writer, projectID string) error {
 	}
 
 	// Call the API to list parameters.
+	count := 0
 	parameters := client.ListParameters(ctx, req)
 	for {
+		// Stop promptly if the context is cancelled between parameters.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		parameter, err := parameters.Next()
 		if err == iterator.Done {
 			break
@@ -59,8 +79,10 @@ func listParams(w io.Writer, projectID string) error {
 		}
 
 		fmt.Fprintf(w, "Found parameter %s with format %s \n", parameter.Name, parameter.Format.String())
+		count++
 	}
 
+	fmt.Fprintf(w, "Found %d parameters\n", count)
 	return nil
 }
 
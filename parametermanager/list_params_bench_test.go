@@ -0,0 +1,51 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// BenchmarkListParams measures listParamsWithClient against an in-process fake server
+// seeded with N parameters, so it runs without a project or network access:
+//
+//	go test -run '^$' -bench BenchmarkListParams -benchmem
+//
+// The server runs in the same process and its results are paged, fakeServerDefaultPageSize
+// parameters per call. ns/op and allocs/op therefore cover a full listing: the client
+// iterator, the gRPC marshalling on both sides of the in-memory connection, and the
+// formatted output. The absolute numbers include the fake server's share and say
+// little about production latency, which is dominated by the network. What matters is
+// how they grow with N: allocs/op should grow linearly with the number of parameters,
+// and a jump between sizes points at per-page or per-item overhead in the sample.
+func BenchmarkListParams(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("N=%d", n), func(b *testing.B) {
+			client := newFakeServerClient(b, newFakeParameterManagerServer("project", n))
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := listParamsWithClient(ctx, client, io.Discard, "project"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_list_params_by_label]
+import (
+	"context"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// listParamsByLabel lists the parameters in a project that carry a given label
+// using the Parameter Manager SDK for GCP.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+// labelKey: The key of the label to filter on.
+// labelValue: The value the label must have.
+//
+// The function returns an error if the parameter listing fails, including the
+// service's explanation when the filter is rejected as invalid.
+func listParamsByLabel(w io.Writer, projectID, labelKey, labelValue string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the parent and the label filter.
+	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
+	filter := fmt.Sprintf("labels.%s=%s", labelKey, labelValue)
+
+	// Build the request to list parameters matching the filter.
+	req := &parametermanagerpb.ListParametersRequest{
+		Parent: parent,
+		Filter: filter,
+	}
+
+	// Call the API to list parameters.
+	count := 0
+	parameters := client.ListParameters(ctx, req)
+	for {
+		parameter, err := parameters.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			if s, ok := status.FromError(err); ok && s.Code() == codes.InvalidArgument {
+				return fmt.Errorf("invalid filter %q: %s", filter, s.Message())
+			}
+			return fmt.Errorf("failed to list parameters: %w", err)
+		}
+
+		fmt.Fprintf(w, "Found parameter %s\n", parameter.Name)
+		count++
+	}
+
+	fmt.Fprintf(w, "Found %d parameters with label %s=%s\n", count, labelKey, labelValue)
+	return nil
+}
+
+// [END parametermanager_list_params_by_label]
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_list_params_json]
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// paramSummary is a single entry in the JSON written by listParamsJSON.
+type paramSummary struct {
+	// Name is the full resource name of the parameter.
+	Name string `json:"name"`
+	// Format is the format of the parameter, e.g. "JSON".
+	Format string `json:"format"`
+	// KmsKey is the customer-managed encryption key, if any.
+	KmsKey string `json:"kmsKey,omitempty"`
+}
+
+// listParamsJSON lists all parameters in a project and writes them as an indented JSON
+// array using the Parameter Manager SDK for GCP, so the output can be piped to tools
+// such as jq. An empty project produces "[]".
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+//
+// The function returns an error if the parameter listing or the JSON encoding fails.
+func listParamsJSON(w io.Writer, projectID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Build the request to list parameters.
+	req := &parametermanagerpb.ListParametersRequest{
+		Parent: fmt.Sprintf("projects/%s/locations/global", projectID),
+	}
+
+	// Drain the iterator, checking the context before each parameter.
+	summaries := make([]paramSummary, 0)
+	parameters := client.ListParameters(ctx, req)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		parameter, err := parameters.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameters: %w", err)
+		}
+
+		summaries = append(summaries, paramSummary{
+			Name:   parameter.Name,
+			Format: parameter.Format.String(),
+			KmsKey: parameter.GetKmsKey(),
+		})
+	}
+
+	// Write the parameters as indented JSON.
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(summaries); err != nil {
+		return fmt.Errorf("failed to encode parameters: %w", err)
+	}
+	return nil
+}
+
+// [END parametermanager_list_params_json]
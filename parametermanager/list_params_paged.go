@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_list_params_paged]
+import (
+	"context"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// listParamsPaged lists all parameters in a project one page at a time using the
+// Parameter Manager SDK for GCP. Only the current page is held in memory, and a
+// marker is printed at the start of each page.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+// pageSize: The maximum number of parameters to fetch per page.
+//
+// The function returns an error if the parameter listing fails.
+func listParamsPaged(w io.Writer, projectID string, pageSize int32) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Build the request to list parameters with the requested page size.
+	req := &parametermanagerpb.ListParametersRequest{
+		Parent:   fmt.Sprintf("projects/%s/locations/global", projectID),
+		PageSize: pageSize,
+	}
+
+	// The pager fetches exactly one page from the API per NextPage call.
+	it := client.ListParameters(ctx, req)
+	pager := iterator.NewPager(it, int(pageSize), "")
+
+	pages, count := 0, 0
+	for {
+		var page []*parametermanagerpb.Parameter
+		nextPageToken, err := pager.NextPage(&page)
+		if err != nil {
+			return fmt.Errorf("failed to list parameters: %w", err)
+		}
+
+		pages++
+		fmt.Fprintf(w, "--- Page %d (%d parameters) ---\n", pages, len(page))
+		for _, parameter := range page {
+			fmt.Fprintf(w, "Found parameter %s with format %s\n", parameter.Name, parameter.Format)
+		}
+		count += len(page)
+
+		if nextPageToken == "" {
+			break
+		}
+	}
+
+	fmt.Fprintf(w, "Found %d parameters in %d pages\n", count, pages)
+	return nil
+}
+
+// [END parametermanager_list_params_paged]
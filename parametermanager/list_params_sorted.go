@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_list_params_sorted]
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// listParamsSorted lists all parameters in a project ordered by creation time using the
+// Parameter Manager SDK for GCP.
+//
+// The order_by field of ListParametersRequest is only a hint that the service may ignore,
+// so the parameters are collected and sorted on the client. The sort is stable, so
+// parameters created at the same time keep the order they were listed in.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+// newestFirst: Whether to print the most recently created parameters first.
+//
+// The function returns an error if the parameter listing fails.
+func listParamsSorted(w io.Writer, projectID string, newestFirst bool) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return listParamsSortedWithClient(ctx, client, w, projectID, newestFirst)
+}
+
+// listParamsSortedWithClient collects the parameters from client and prints them after a
+// stable sort on their creation time.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+// newestFirst: Whether to print the most recently created parameters first.
+//
+// The function returns an error if the parameter listing fails.
+func listParamsSortedWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID string, newestFirst bool) error {
+	// Construct the parent location and build the request to list parameters.
+	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
+	req := &parametermanagerpb.ListParametersRequest{
+		Parent: parent,
+	}
+
+	// Call the API to list parameters and collect every page.
+	var parameters []*parametermanagerpb.Parameter
+	it := client.ListParameters(ctx, req)
+	for {
+		parameter, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameters: %w", err)
+		}
+		parameters = append(parameters, parameter)
+	}
+
+	sort.SliceStable(parameters, func(i, j int) bool {
+		a, b := parameters[i].GetCreateTime().AsTime(), parameters[j].GetCreateTime().AsTime()
+		if newestFirst {
+			return a.After(b)
+		}
+		return a.Before(b)
+	})
+
+	for _, parameter := range parameters {
+		createTime := parameter.GetCreateTime().AsTime().UTC().Format(time.RFC3339Nano)
+		fmt.Fprintf(w, "Found parameter %s created at %s\n", parameter.Name, createTime)
+	}
+	return nil
+}
+
+// [END parametermanager_list_params_sorted]
@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_list_params_table]
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// listParamsTable lists all parameters in a project as a tab-aligned table using the
+// Parameter Manager SDK for GCP. Parameters without a customer-managed encryption key
+// show "-" in the KMS_KEY column.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+//
+// The function returns an error if the parameter listing fails.
+func listParamsTable(w io.Writer, projectID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the parent location and build the request to list parameters.
+	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
+	req := &parametermanagerpb.ListParametersRequest{
+		Parent: parent,
+	}
+
+	// Align the columns with tabwriter; nothing is written to w until Flush.
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PARAMETER\tFORMAT\tKMS_KEY\tCREATE_TIME")
+
+	// Call the API to list parameters and add a row for each one.
+	parameters := client.ListParameters(ctx, req)
+	for {
+		parameter, err := parameters.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameters: %w", err)
+		}
+
+		kmsKey := parameter.GetKmsKey()
+		if kmsKey == "" {
+			kmsKey = "-"
+		}
+		createTime := parameter.GetCreateTime().AsTime().UTC().Format(time.RFC3339)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", parameter.Name, parameter.Format, kmsKey, createTime)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write parameter table: %w", err)
+	}
+	return nil
+}
+
+// [END parametermanager_list_params_table]
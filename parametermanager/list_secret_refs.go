@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_list_secret_refs]
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// secretRefRE matches a secret reference such as
+// __REF__(//secretmanager.googleapis.com/projects/p/secrets/s/versions/1), with or
+// without quotes around the reference, and captures the secret version resource name.
+// The quotes may be escaped, as they are when the reference sits inside a JSON string.
+var secretRefRE = regexp.MustCompile(`__REF__\(\s*\\?"?//secretmanager\.googleapis\.com/([^"\\)\s]+)\\?"?\s*\)`)
+
+// listSecretReferences lists the Secret Manager secret versions a parameter version
+// references using the Parameter Manager SDK for GCP. The stored (unrendered) payload is
+// scanned, so no secrets are accessed. Each reference is returned once, in the order it
+// first appears.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter holding the version.
+// versionID: The ID of the version to be scanned.
+//
+// The function returns an error if the parameter version retrieval fails.
+func listSecretReferences(w io.Writer, projectID, parameterID, versionID string) ([]string, error) {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter version to scan.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+
+	// Call the API to get the stored payload. Unlike RenderParameterVersion, this
+	// leaves the secret references unresolved.
+	version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parameter version: %w", err)
+	}
+
+	var refs []string
+	seen := make(map[string]bool)
+	for _, match := range secretRefRE.FindAllSubmatch(version.GetPayload().GetData(), -1) {
+		ref := string(match[1])
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+		fmt.Fprintf(w, "Found secret reference %s\n", ref)
+	}
+
+	fmt.Fprintf(w, "Found %d secret references in %s\n", len(refs), version.Name)
+	return refs, nil
+}
+
+// [END parametermanager_list_secret_refs]
@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_list_used_kms_keys]
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"text/tabwriter"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// listUsedKmsKeys prints each Cloud KMS key used by the parameters in a project, with the
+// number of parameters that use it, using the Parameter Manager SDK for GCP. This shows
+// which keys a key rotation affects. Parameters without a key are counted under
+// "Google-managed", which is printed last.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+//
+// The function returns an error if the parameter listing fails.
+func listUsedKmsKeys(w io.Writer, projectID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return listUsedKmsKeysWithClient(ctx, client, w, projectID)
+}
+
+// listUsedKmsKeysWithClient lists the parameters through client and tallies them by
+// their kms_key field.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+//
+// The function returns an error if the parameter listing fails.
+func listUsedKmsKeysWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID string) error {
+	// Construct the parent location and call the API to list parameters.
+	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
+	parameters := client.ListParameters(ctx, &parametermanagerpb.ListParametersRequest{
+		Parent: parent,
+	})
+
+	counts := make(map[string]int)
+	googleManaged := 0
+	for {
+		parameter, err := parameters.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameters: %w", err)
+		}
+		if kmsKey := parameter.GetKmsKey(); kmsKey != "" {
+			counts[kmsKey]++
+		} else {
+			googleManaged++
+		}
+	}
+
+	// Align the columns with tabwriter; nothing is written to w until Flush.
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KMS_KEY\tPARAMETERS")
+	for _, kmsKey := range slices.Sorted(maps.Keys(counts)) {
+		fmt.Fprintf(tw, "%s\t%d\n", kmsKey, counts[kmsKey])
+	}
+	fmt.Fprintf(tw, "Google-managed\t%d\n", googleManaged)
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write KMS key report: %w", err)
+	}
+	return nil
+}
+
+// [END parametermanager_list_used_kms_keys]
@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_list_versions_since]
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// listVersionsSince lists the versions of a parameter created at or after a given time,
+// oldest first, using the Parameter Manager SDK for GCP.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the versions are to be listed.
+// since: The earliest creation time of the versions to be printed.
+//
+// The function returns an error if the parameter version listing fails.
+func listVersionsSince(w io.Writer, projectID, parameterID string, since time.Time) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return listVersionsSinceWithClient(ctx, client, w, projectID, parameterID, since)
+}
+
+// listVersionsSinceWithClient lists the versions through client and prints, oldest first,
+// those created at or after since.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the versions are to be listed.
+// since: The earliest creation time of the versions to be printed.
+//
+// The function returns an error if the parameter version listing fails.
+func listVersionsSinceWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID string, since time.Time) error {
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Call the API to list parameter versions and keep those created since the given time.
+	var recent []*parametermanagerpb.ParameterVersion
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: parent,
+	})
+	for {
+		version, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		if !version.GetCreateTime().AsTime().Before(since) {
+			recent = append(recent, version)
+		}
+	}
+
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].GetCreateTime().AsTime().Before(recent[j].GetCreateTime().AsTime())
+	})
+
+	for _, version := range recent {
+		createTime := version.GetCreateTime().AsTime().UTC().Format(time.RFC3339Nano)
+		fmt.Fprintf(w, "Found parameter version %s created at %s\n", version.Name, createTime)
+	}
+	fmt.Fprintf(w, "Found %d parameter versions created since %s\n", len(recent), since.UTC().Format(time.RFC3339))
+	return nil
+}
+
+// [END parametermanager_list_versions_since]
@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_load_bundle]
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/iterator"
+)
+
+// ParamBundle holds a parameter and the rendered payloads of its versions, as returned
+// by loadParamBundle.
+type ParamBundle struct {
+	// Parameter is the parameter itself.
+	Parameter *parametermanagerpb.Parameter
+	// Versions holds the rendered enabled versions, in the order they were listed.
+	Versions []BundleVersion
+	// Failed maps the names of the versions that could not be rendered to the render
+	// error. It is only populated when rendering is best effort.
+	Failed map[string]error
+}
+
+// BundleVersion is a rendered parameter version in a ParamBundle.
+type BundleVersion struct {
+	// Name is the full resource name of the version.
+	Name string
+	// RenderedPayload is the payload with secret references resolved.
+	RenderedPayload []byte
+}
+
+// loadParamBundle fetches a parameter and renders all its enabled versions concurrently
+// using the Parameter Manager SDK for GCP, so a caller gets the whole parameter in one
+// call. Disabled versions cannot be rendered and are left out.
+//
+// At most 10 versions are rendered at a time. Unless bestEffort is set, the first failed
+// render cancels the others.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be loaded.
+// bestEffort: Whether to leave versions that fail to render out of the bundle rather
+// than fail.
+//
+// The function returns an error if the parameter retrieval or version listing fails, or
+// if a render fails and bestEffort is false.
+func loadParamBundle(ctx context.Context, projectID, parameterID string, bestEffort bool) (*ParamBundle, error) {
+	// Create a Parameter Manager client shared by all the fetches.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return loadParamBundleWithClient(ctx, client, projectID, parameterID, bestEffort)
+}
+
+// loadParamBundleWithClient gets the parameter through client, lists its versions, and
+// renders the enabled ones, at most 10 at a time.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be loaded.
+// bestEffort: Whether to leave versions that fail to render out of the bundle rather
+// than fail.
+//
+// The function returns an error if the parameter retrieval or version listing fails, or
+// if a render fails and bestEffort is false.
+func loadParamBundleWithClient(ctx context.Context, client ParameterClient, projectID, parameterID string, bestEffort bool) (*ParamBundle, error) {
+	// Construct the name of the parameter to load.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// List the versions to find the ones to render.
+	var names []string
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: name,
+	})
+	for {
+		version, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		if !version.Disabled {
+			names = append(names, version.Name)
+		}
+	}
+
+	// Get the parameter and render the versions with bounded concurrency.
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(10)
+
+	bundle := &ParamBundle{}
+	g.Go(func() error {
+		parameter, err := client.GetParameter(gctx, &parametermanagerpb.GetParameterRequest{
+			Name: name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get parameter: %w", err)
+		}
+		bundle.Parameter = parameter
+		return nil
+	})
+
+	var mu sync.Mutex
+	rendered := make([]*BundleVersion, len(names))
+	failed := make(map[string]error)
+	for i, versionName := range names {
+		g.Go(func() error {
+			resp, err := client.RenderParameterVersion(gctx, &parametermanagerpb.RenderParameterVersionRequest{
+				Name: versionName,
+			})
+			if err != nil && !bestEffort {
+				return fmt.Errorf("failed to render parameter version %s: %w", versionName, err)
+			}
+			if err != nil {
+				mu.Lock()
+				failed[versionName] = err
+				mu.Unlock()
+				return nil
+			}
+			// Each goroutine writes only its own element, so no lock is needed.
+			rendered[i] = &BundleVersion{Name: versionName, RenderedPayload: resp.RenderedPayload}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	for _, version := range rendered {
+		if version != nil {
+			bundle.Versions = append(bundle.Versions, *version)
+		}
+	}
+	if bestEffort {
+		bundle.Failed = failed
+	}
+	return bundle, nil
+}
+
+// [END parametermanager_load_bundle]
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_merge_params]
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// ParamRef identifies a version of a parameter in the global location.
+type ParamRef struct {
+	// ParameterID is the ID of the parameter.
+	ParameterID string
+	// VersionID is the ID of the parameter version.
+	VersionID string
+}
+
+// mergeParams renders JSON parameter versions in order and deep-merges them into a single
+// map using the Parameter Manager SDK for GCP, with later versions overriding earlier
+// ones. Nested objects are merged key by key; any other value, including an array,
+// replaces the earlier value.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameters are located.
+// refs: The parameter versions to merge, from lowest to highest precedence.
+//
+// The function returns an error if a rendering fails or a rendered payload is not a
+// JSON object.
+func mergeParams(ctx context.Context, projectID string, refs []ParamRef) (map[string]interface{}, error) {
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	merged := make(map[string]interface{})
+	for _, ref := range refs {
+		name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, ref.ParameterID, ref.VersionID)
+
+		// Call the API to render the parameter version.
+		rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+			Name: name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to render parameter version %s: %w", name, err)
+		}
+
+		var layer map[string]interface{}
+		if err := json.Unmarshal(rendered.RenderedPayload, &layer); err != nil {
+			return nil, fmt.Errorf("failed to decode %s as a JSON object: %w", name, err)
+		}
+		deepMerge(merged, layer)
+	}
+	return merged, nil
+}
+
+// deepMerge merges src into dst. Where both hold an object for a key the objects are
+// merged recursively; otherwise the value from src wins.
+func deepMerge(dst, src map[string]interface{}) {
+	for key, srcValue := range src {
+		srcMap, srcIsMap := srcValue.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			deepMerge(dstMap, srcMap)
+			continue
+		}
+		dst[key] = srcValue
+	}
+}
+
+// [END parametermanager_merge_params]
@@ -0,0 +1,167 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_merge_versions]
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// mergeVersions deep-merges one version of a JSON parameter over another and stores the
+// result as a new version, using the Parameter Manager SDK for GCP.
+//
+// The stored payloads are merged rather than the rendered ones, so secret references are
+// copied into the new version unresolved instead of as secret values. Nested objects are
+// merged key by key and any other overlay value replaces the base value, but a key that
+// holds an object in one version and a non-object in the other is an error. Numbers are
+// copied exactly as stored, so large integers keep their precision.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the JSON parameter.
+// baseVersionID: The ID of the version to merge onto.
+// overlayVersionID: The ID of the version whose values take precedence.
+// outVersionID: The ID of the version to be created with the merged payload.
+//
+// The function returns an error if the parameter is not a JSON parameter, a version cannot
+// be read or is not a JSON object, the versions conflict, or the version creation fails.
+func mergeVersions(w io.Writer, projectID, parameterID, baseVersionID, overlayVersionID, outVersionID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return mergeVersionsWithClient(ctx, client, w, projectID, parameterID, baseVersionID, overlayVersionID, outVersionID)
+}
+
+// mergeVersionsWithClient reads the stored payloads of the two versions through client,
+// merges them with deepMergeStrict, and creates the output version from the result.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the JSON parameter.
+// baseVersionID: The ID of the version to merge onto.
+// overlayVersionID: The ID of the version whose values take precedence.
+// outVersionID: The ID of the version to be created with the merged payload.
+//
+// The function returns an error if the parameter is not a JSON parameter, a version cannot
+// be read or is not a JSON object, the versions conflict, or the version creation fails.
+func mergeVersionsWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, baseVersionID, overlayVersionID, outVersionID string) error {
+	// Check the parameter holds JSON.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter: %w", err)
+	}
+	if parameter.Format != parametermanagerpb.ParameterFormat_JSON {
+		return fmt.Errorf("parameter %s has format %s: only JSON parameters can be merged", name, parameter.Format)
+	}
+
+	// Read and decode the stored payload of both versions. Decode numbers as json.Number
+	// so they are written exactly as stored.
+	var layers [2]map[string]interface{}
+	for i, versionID := range []string{baseVersionID, overlayVersionID} {
+		version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+			Name: fmt.Sprintf("%s/versions/%s", name, versionID),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get parameter version %s: %w", versionID, err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(version.GetPayload().GetData()))
+		dec.UseNumber()
+		if err := dec.Decode(&layers[i]); err != nil {
+			return fmt.Errorf("failed to decode version %s as a JSON object: %w", versionID, err)
+		}
+		if _, err := dec.Token(); err != io.EOF {
+			return fmt.Errorf("failed to decode version %s as a JSON object: unexpected data after the object", versionID)
+		}
+	}
+
+	merged := layers[0]
+	if merged == nil {
+		merged = make(map[string]interface{})
+	}
+	if err := deepMergeStrict(merged, layers[1], ""); err != nil {
+		return err
+	}
+	payload, err := json.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("failed to encode merged payload: %w", err)
+	}
+
+	// Call the API to create the parameter version with the merged payload.
+	version, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             name,
+		ParameterVersionId: outVersionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	fmt.Fprintf(w, "Created merged parameter version: %s\n", version.Name)
+	return nil
+}
+
+// deepMergeStrict merges src into dst like deepMerge, but returns an error naming the
+// dotted path of the first key, in sorted order, that holds an object on one side only.
+// path is the location of dst within the top-level object.
+func deepMergeStrict(dst, src map[string]interface{}, path string) error {
+	// Walk the keys in sorted order so the same conflict is reported on every run.
+	for _, key := range slices.Sorted(maps.Keys(src)) {
+		srcValue := src[key]
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+
+		dstValue, exists := dst[key]
+		srcMap, srcIsMap := srcValue.(map[string]interface{})
+		dstMap, dstIsMap := dstValue.(map[string]interface{})
+		switch {
+		case srcIsMap && dstIsMap:
+			if err := deepMergeStrict(dstMap, srcMap, keyPath); err != nil {
+				return err
+			}
+		case exists && srcIsMap != dstIsMap:
+			return fmt.Errorf("cannot merge %s: object and non-object values conflict", keyPath)
+		default:
+			dst[key] = srcValue
+		}
+	}
+	return nil
+}
+
+// [END parametermanager_merge_versions]
@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_migrate_to_json]
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+// migrateToJSON changes an UNFORMATTED parameter to the JSON format once every version
+// has been checked to hold valid JSON, using the Parameter Manager SDK for GCP. Changing
+// the format does not rewrite or validate existing versions, so without the check a
+// JSON parameter could be left with versions that are not JSON.
+//
+// Each version is printed with PASS or FAIL. A disabled version whose payload is not
+// returned cannot be checked and fails.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the UNFORMATTED parameter to be migrated.
+// dryRun: Whether to only check the versions and leave the format unchanged.
+//
+// The function returns an error if the parameter is not UNFORMATTED, a version cannot be
+// read, any version is not valid JSON, or the parameter update fails.
+func migrateToJSON(w io.Writer, projectID, parameterID string, dryRun bool) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return migrateToJSONWithClient(ctx, client, w, projectID, parameterID, dryRun)
+}
+
+// migrateToJSONWithClient gets every version of the parameter through client to check
+// its payload, and updates the format only if all of them pass.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the UNFORMATTED parameter to be migrated.
+// dryRun: Whether to only check the versions and leave the format unchanged.
+//
+// The function returns an error if the parameter is not UNFORMATTED, a version cannot be
+// read, any version is not valid JSON, or the parameter update fails.
+func migrateToJSONWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID string, dryRun bool) error {
+	// Check the parameter is UNFORMATTED.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter: %w", err)
+	}
+	if parameter.Format != parametermanagerpb.ParameterFormat_UNFORMATTED {
+		return fmt.Errorf("parameter %s has format %s: only UNFORMATTED parameters can be migrated", name, parameter.Format)
+	}
+
+	// List the versions, then get each one, since listing does not return payloads.
+	var names []string
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: name,
+	})
+	for {
+		version, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		names = append(names, version.Name)
+	}
+
+	failed := 0
+	for _, versionName := range names {
+		version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+			Name: versionName,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get parameter version %s: %w", versionName, err)
+		}
+
+		payload := version.GetPayload().GetData()
+		switch {
+		case version.Disabled && len(payload) == 0:
+			fmt.Fprintf(w, "FAIL %s: disabled version, payload not available to check\n", versionName)
+			failed++
+		case !json.Valid(payload):
+			fmt.Fprintf(w, "FAIL %s: payload is not valid JSON\n", versionName)
+			failed++
+		default:
+			fmt.Fprintf(w, "PASS %s\n", versionName)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d versions of %s are not valid JSON; the parameter was not migrated", failed, len(names), name)
+	}
+
+	if dryRun {
+		fmt.Fprintf(w, "Would update parameter %s format to JSON\n", name)
+		return nil
+	}
+
+	// Call the API to update only the format of the parameter.
+	updated, err := client.UpdateParameter(ctx, &parametermanagerpb.UpdateParameterRequest{
+		Parameter: &parametermanagerpb.Parameter{
+			Name:   name,
+			Format: parametermanagerpb.ParameterFormat_JSON,
+		},
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"format"},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update parameter format: %w", err)
+	}
+	fmt.Fprintf(w, "Updated parameter %s format to %s\n", updated.Name, updated.Format)
+	return nil
+}
+
+// [END parametermanager_migrate_to_json]
@@ -0,0 +1,52 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var (
+	// parameterNameRE matches "projects/{project}/locations/{location}/parameters/{parameter}".
+	parameterNameRE = regexp.MustCompile(`^projects/([^/]+)/locations/([^/]+)/parameters/([^/]+)$`)
+	// versionNameRE matches "projects/{project}/locations/{location}/parameters/{parameter}/versions/{version}".
+	versionNameRE = regexp.MustCompile(`^projects/([^/]+)/locations/([^/]+)/parameters/([^/]+)/versions/([^/]+)$`)
+)
+
+// ParseParameterName splits a parameter resource name of the form
+// "projects/{project}/locations/{location}/parameters/{parameter}" into its IDs.
+//
+// The function returns an error if name is not a parameter resource name.
+func ParseParameterName(name string) (projectID, locationID, parameterID string, err error) {
+	m := parameterNameRE.FindStringSubmatch(name)
+	if m == nil {
+		return "", "", "", fmt.Errorf("invalid parameter name %q: must be of the form projects/{project}/locations/{location}/parameters/{parameter}", name)
+	}
+	return m[1], m[2], m[3], nil
+}
+
+// ParseVersionName splits a parameter version resource name of the form
+// "projects/{project}/locations/{location}/parameters/{parameter}/versions/{version}"
+// into its IDs.
+//
+// The function returns an error if name is not a parameter version resource name.
+func ParseVersionName(name string) (projectID, locationID, parameterID, versionID string, err error) {
+	m := versionNameRE.FindStringSubmatch(name)
+	if m == nil {
+		return "", "", "", "", fmt.Errorf("invalid parameter version name %q: must be of the form projects/{project}/locations/{location}/parameters/{parameter}/versions/{version}", name)
+	}
+	return m[1], m[2], m[3], m[4], nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_notify_on_version_create]
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"cloud.google.com/go/pubsub"
+)
+
+// notifyOnVersionCreate creates a parameter version and then publishes a Pub/Sub message
+// announcing it, using the Parameter Manager SDK for GCP.
+//
+// The message carries the parameter name, the version ID and the stored payload size as
+// the attributes "parameter", "version" and "payload_size", and the version name as its
+// data. The payload itself is not published, since it may hold secret references that
+// subscribers should render for themselves. The version is the source of truth, so a
+// failure to publish is reported but does not fail the function.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter and the topic are located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created.
+// topicID: The ID of the Pub/Sub topic to be notified.
+// payload: The payload to be stored in the new parameter version.
+//
+// The function returns an error if the version creation fails.
+func notifyOnVersionCreate(w io.Writer, projectID, parameterID, versionID, topicID string, payload []byte) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Build the request to create the parameter version.
+	req := &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	}
+
+	// Call the API to create the parameter version.
+	version, err := client.CreateParameterVersion(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+	fmt.Fprintf(w, "Created parameter version: %s\n", version.Name)
+
+	if err := publishVersionCreated(ctx, projectID, topicID, parent, versionID, version.Name, len(payload)); err != nil {
+		fmt.Fprintf(w, "Failed to publish notification to topic %s: %v\n", topicID, err)
+		return nil
+	}
+	fmt.Fprintf(w, "Published notification to topic %s\n", topicID)
+	return nil
+}
+
+// publishVersionCreated publishes the notification for a new version and waits for the
+// server to accept it.
+func publishVersionCreated(ctx context.Context, projectID, topicID, parameterName, versionID, versionName string, payloadSize int) error {
+	client, err := pubsub.NewClient(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to create Pub/Sub client: %w", err)
+	}
+	defer client.Close()
+
+	topic := client.Topic(topicID)
+	defer topic.Stop()
+
+	result := topic.Publish(ctx, &pubsub.Message{
+		Data: []byte(versionName),
+		Attributes: map[string]string{
+			"parameter":    parameterName,
+			"version":      versionID,
+			"payload_size": strconv.Itoa(payloadSize),
+		},
+	})
+	if _, err := result.Get(ctx); err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+	return nil
+}
+
+// [END parametermanager_notify_on_version_create]
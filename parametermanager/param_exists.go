@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_param_exists]
+import (
+	"context"
+	"fmt"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// paramExists reports whether a parameter exists using the Parameter Manager SDK for GCP.
+//
+// Only NotFound is treated as "does not exist". Any other error, such as
+// PermissionDenied, is returned unchanged so callers do not mistake a missing
+// permission for a missing parameter.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to look up.
+//
+// The function returns an error if the lookup fails for any reason other than NotFound.
+func paramExists(ctx context.Context, projectID, parameterID string) (bool, error) {
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return paramExistsWithClient(ctx, client, projectID, parameterID)
+}
+
+// paramExistsWithClient gets the parameter through client and maps a NotFound status to
+// false.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to look up.
+//
+// The function returns an error if the lookup fails for any reason other than NotFound.
+func paramExistsWithClient(ctx context.Context, client ParameterClient, projectID, parameterID string) (bool, error) {
+	// Construct the name of the parameter to look up.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Call the API to get the parameter.
+	_, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err == nil {
+		return true, nil
+	}
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	return false, err
+}
+
+// [END parametermanager_param_exists]
@@ -16,19 +16,44 @@ package parametermanager
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"cloud.google.com/go/iam"
 	kms "cloud.google.com/go/kms/apiv1"
 	"cloud.google.com/go/kms/apiv1/kmspb"
 	parametermanager "cloud.google.com/go/parametermanager/apiv1"
 	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"cloud.google.com/go/pubsub"
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
 	"github.com/GoogleCloudPlatform/golang-samples/internal/testutil"
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/gofrs/uuid"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/genproto/protobuf/field_mask"
 	grpccodes "google.golang.org/grpc/codes"
 	grpcstatus "google.golang.org/grpc/status"
 )
@@ -45,16 +70,16 @@ func testName(t *testing.T) string {
 	return u.String()
 }
 
-// testParameter creates a parameter in the specified GCP project with the given format.
+// testParameterWithKmsKey creates a parameter with a KMS key in the specified GCP project.
 // It returns the created parameter and its ID or fails the test if parameter creation fails.
-func testParameter(t *testing.T, projectID string, format parametermanagerpb.ParameterFormat) (*parametermanagerpb.Parameter, string) {
+func testParameterWithKmsKey(t *testing.T, projectID, kms_key string) (*parametermanagerpb.Parameter, string) {
 	t.Helper()
-	parameterID := testName(t)
 
+	parameterID := testName(t)
 	ctx := context.Background()
 	client, err := parametermanager.NewClient(ctx)
 	if err != nil {
-		t.Fatalf("testClient: failed to create client: %v", err)
+		t.Fatalf("failed to create client: %v", err)
 	}
 	defer client.Close()
 
@@ -63,7 +88,8 @@ func testParameter(t *testing.T, projectID string, format parametermanagerpb.Par
 		Parent:      parent,
 		ParameterId: parameterID,
 		Parameter: &parametermanagerpb.Parameter{
-			Format: format,
+			Format: parametermanagerpb.ParameterFormat_UNFORMATTED,
+			KmsKey: &kms_key,
 		},
 	})
 	if err != nil {
@@ -73,9 +99,9 @@ func testParameter(t *testing.T, projectID string, format parametermanagerpb.Par
 	return parameter, parameterID
 }
 
-// testParameterWithKmsKey creates a parameter with a KMS key in the specified GCP project.
+// testParameterWithLabels creates an UNFORMATTED parameter with the given labels in the specified GCP project.
 // It returns the created parameter and its ID or fails the test if parameter creation fails.
-func testParameterWithKmsKey(t *testing.T, projectID, kms_key string) (*parametermanagerpb.Parameter, string) {
+func testParameterWithLabels(t *testing.T, projectID string, labels map[string]string) (*parametermanagerpb.Parameter, string) {
 	t.Helper()
 
 	parameterID := testName(t)
@@ -92,23 +118,21 @@ func testParameterWithKmsKey(t *testing.T, projectID, kms_key string) (*paramete
 		ParameterId: parameterID,
 		Parameter: &parametermanagerpb.Parameter{
 			Format: parametermanagerpb.ParameterFormat_UNFORMATTED,
-			KmsKey: &kms_key,
+			Labels: labels,
 		},
 	})
 	if err != nil {
-		t.Fatalf("testParameter: failed to create parameter: %v", err)
+		t.Fatalf("testParameterWithLabels: failed to create parameter: %v", err)
 	}
 
 	return parameter, parameterID
 }
 
-// testParameterVersion creates a version of a parameter with the given payload in the specified GCP project.
-// It returns the created parameter version and its ID or fails the test if parameter version creation fails.
-func testParameterVersion(t *testing.T, projectID, parameterID, payload string) (*parametermanagerpb.ParameterVersion, string) {
+// testDisableParameterVersion disables the specified parameter version in the GCP project.
+// It returns the updated parameter version or fails the test if the update fails.
+func testDisableParameterVersion(t *testing.T, name string) *parametermanagerpb.ParameterVersion {
 	t.Helper()
 
-	parameterVersionID := testName(t)
-
 	ctx := context.Background()
 	client, err := parametermanager.NewClient(ctx)
 	if err != nil {
@@ -116,22 +140,64 @@ func testParameterVersion(t *testing.T, projectID, parameterID, payload string)
 	}
 	defer client.Close()
 
-	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
-
-	parameterVersion, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
-		Parent:             parent,
-		ParameterVersionId: parameterVersionID,
+	parameterVersion, err := client.UpdateParameterVersion(ctx, &parametermanagerpb.UpdateParameterVersionRequest{
 		ParameterVersion: &parametermanagerpb.ParameterVersion{
-			Payload: &parametermanagerpb.ParameterVersionPayload{
-				Data: []byte(payload),
-			},
+			Name:     name,
+			Disabled: true,
 		},
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"disabled"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("testDisableParameterVersion: failed to disable parameter version: %v", err)
+	}
+
+	return parameterVersion
+}
+
+// testGetParameterVersion retrieves the specified parameter version from the GCP project.
+// It returns the parameter version or fails the test if the retrieval fails.
+func testGetParameterVersion(t *testing.T, name string) *parametermanagerpb.ParameterVersion {
+	t.Helper()
+
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("testClient: failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	parameterVersion, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		t.Fatalf("testGetParameterVersion: failed to get parameter version: %v", err)
+	}
+
+	return parameterVersion
+}
+
+// testGetParameter retrieves the specified parameter from the GCP project.
+// It returns the parameter or fails the test if the parameter retrieval fails.
+func testGetParameter(t *testing.T, name string) *parametermanagerpb.Parameter {
+	t.Helper()
+
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("testClient: failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
 	})
 	if err != nil {
-		t.Fatalf("testParameterVersion: failed to create parameter version: %v", err)
+		t.Fatalf("testGetParameter: failed to get parameter: %v", err)
 	}
 
-	return parameterVersion, parameterVersionID
+	return parameter
 }
 
 // testCleanupParameter deletes the specified parameter in the GCP project.
@@ -381,55 +447,90 @@ func testCleanupSecret(t *testing.T, name string) {
 }
 
 // TestCreateParam tests the createParam function by creating a parameter,
-// then verifies if the parameter was successfully created by checking the output.
+// then verifies if the parameter was successfully created by checking the output,
+// and that creating it again fails with AlreadyExists. It runs against an in-memory
+// server when no project is configured.
 func TestCreateParam(t *testing.T) {
-	tc := testutil.SystemTest(t)
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
 
 	parameterID := testName(t)
 
 	var buf bytes.Buffer
-	if err := createParam(&buf, tc.ProjectID, parameterID); err != nil {
+	if err := createParamWithClient(ctx, client, &buf, projectID, parameterID); err != nil {
 		t.Fatal(err)
 	}
-	defer testCleanupParameter(t, fmt.Sprintf("projects/%s/locations/global/parameters/%s", tc.ProjectID, parameterID))
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	t.Cleanup(func() { testDeleteParameterTreeWithClient(t, client, parameterName) })
 
 	if got, want := buf.String(), "Created parameter:"; !strings.Contains(got, want) {
 		t.Errorf("createParameter: expected %q to contain %q", got, want)
 	}
+
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{Name: parameterName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := parameter.Format, parametermanagerpb.ParameterFormat_UNFORMATTED; got != want {
+		t.Errorf("createParameter: expected format %v, got %v", want, got)
+	}
+
+	err = createParamWithClient(ctx, client, io.Discard, projectID, parameterID)
+	if got, want := grpcstatus.Code(err), grpccodes.AlreadyExists; got != want {
+		t.Errorf("createParameter: expected code %v for a duplicate, got %v", want, got)
+	}
 }
 
-// TestCreateStructuredParam tests the createStructuredParam function by creating a structured parameter,
-// then verifies if the parameter was successfully created by checking the output.
+// TestCreateStructuredParam tests the createStructuredParam function by creating a JSON and a YAML parameter,
+// then verifies if each parameter was successfully created by checking the output.
 func TestCreateStructuredParam(t *testing.T) {
 	tc := testutil.SystemTest(t)
 
-	parameterID := testName(t)
+	for _, format := range []parametermanagerpb.ParameterFormat{
+		parametermanagerpb.ParameterFormat_JSON,
+		parametermanagerpb.ParameterFormat_YAML,
+	} {
+		parameterID := testName(t)
+		parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", tc.ProjectID, parameterID)
 
-	var buf bytes.Buffer
-	if err := createStructuredParam(&buf, tc.ProjectID, parameterID, parametermanagerpb.ParameterFormat_JSON); err != nil {
-		t.Fatal(err)
+		var buf bytes.Buffer
+		if err := createStructuredParam(&buf, tc.ProjectID, parameterID, format); err != nil {
+			t.Fatal(err)
+		}
+		defer testCleanupParameter(t, parameterName)
+
+		if got, want := buf.String(), fmt.Sprintf("Created parameter %s with format %s", parameterName, format); !strings.Contains(got, want) {
+			t.Errorf("createParameter: expected %q to contain %q", got, want)
+		}
 	}
-	defer testCleanupParameter(t, fmt.Sprintf("projects/%s/locations/global/parameters/%s", tc.ProjectID, parameterID))
+}
 
-	if got, want := buf.String(), fmt.Sprintf("Created parameter %s with format JSON", fmt.Sprintf("projects/%s/locations/global/parameters/%s", tc.ProjectID, parameterID)); !strings.Contains(got, want) {
-		t.Errorf("createParameter: expected %q to contain %q", got, want)
+// TestCreateStructuredParamInvalidFormat tests that createStructuredParam rejects
+// an unsupported format before any request is sent.
+func TestCreateStructuredParamInvalidFormat(t *testing.T) {
+	var buf bytes.Buffer
+	err := createStructuredParam(&buf, "my-project", "my-parameter", parametermanagerpb.ParameterFormat_PARAMETER_FORMAT_UNSPECIFIED)
+	if err == nil {
+		t.Fatal("createStructuredParam: expected error for unspecified format, got nil")
+	}
+	if got, want := err.Error(), "invalid parameter format"; !strings.Contains(got, want) {
+		t.Errorf("createStructuredParam: expected %q to contain %q", got, want)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("createStructuredParam: expected no output, got %q", buf.String())
 	}
 }
 
 // TestCreateStructuredParamVersion tests the createStructuredParamVersion function by creating a structured parameter version,
 // then verifies if the parameter version was successfully created by checking the output.
 func TestCreateStructuredParamVersion(t *testing.T) {
-	tc := testutil.SystemTest(t)
-
-	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
 	parameterVersionID := testName(t)
 	payload := `{"username": "test-user", "host": "localhost"}`
 	var buf bytes.Buffer
-	if err := createStructuredParamVersion(&buf, tc.ProjectID, parameterID, parameterVersionID, payload); err != nil {
+	if err := createStructuredParamVersion(&buf, projectID, parameterID, parameterVersionID, payload); err != nil {
 		t.Fatal(err)
 	}
-	defer testCleanupParameter(t, parameter.Name)
-	defer testCleanupParameterVersion(t, fmt.Sprintf("%s/versions/%s", parameter.Name, parameterVersionID))
 
 	if got, want := buf.String(), "Created parameter version:"; !strings.Contains(got, want) {
 		t.Errorf("createParameterVersion: expected %q to contain %q", got, want)
@@ -439,108 +540,164 @@ func TestCreateStructuredParamVersion(t *testing.T) {
 // TestCreateParamVersion tests the createParamVersion function by creating a parameter version,
 // then verifies if the parameter version was successfully created by checking the output.
 func TestCreateParamVersion(t *testing.T) {
-	tc := testutil.SystemTest(t)
-
-	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
 	parameterVersionID := testName(t)
-	payload := "test123"
+	payload := []byte("my-value")
 	var buf bytes.Buffer
-	if err := createParamVersion(&buf, tc.ProjectID, parameterID, parameterVersionID, payload); err != nil {
+	if err := createParamVersion(&buf, projectID, parameterID, parameterVersionID, payload); err != nil {
 		t.Fatal(err)
 	}
-	defer testCleanupParameter(t, parameter.Name)
-	defer testCleanupParameterVersion(t, fmt.Sprintf("%s/versions/%s", parameter.Name, parameterVersionID))
 
 	if got, want := buf.String(), "Created parameter version:"; !strings.Contains(got, want) {
 		t.Errorf("createParameterVersion: expected %q to contain %q", got, want)
 	}
-}
 
-// TestCreateParamVersionWithSecret tests the createParamVersionWithSecret function by creating a parameter version with a secret reference,
-// then verifies if the parameter version was successfully created by checking the output.
-func TestCreateParamVersionWithSecret(t *testing.T) {
-	tc := testutil.SystemTest(t)
+	if got, want := buf.String(), fmt.Sprintf("/versions/%s", parameterVersionID); !strings.Contains(got, want) {
+		t.Errorf("createParameterVersion: expected %q to contain %q", got, want)
+	}
+}
 
-	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+// TestCreateParamVersionWithSecretRef tests the createParamVersionWithSecretRef function by creating a secret
+// and a parameter version referencing it, then verifies the stored payload contains the secret reference.
+func TestCreateParamVersionWithSecretRef(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
 	parameterVersionID := testName(t)
-	secretID := testName(t)
-	payload := fmt.Sprintf("projects/%s/secrets/%s/versions/latest", tc.ProjectID, secretID)
+	secret := testSecret(t, projectID)
+	testSecretVersion(t, secret.Name, []byte("very secret data"))
+	secretID := secret.Name[strings.LastIndex(secret.Name, "/")+1:]
+
+	defer testCleanupSecret(t, secret.Name)
+
 	var buf bytes.Buffer
-	if err := createParamVersionWithSecret(&buf, tc.ProjectID, parameterID, parameterVersionID, payload); err != nil {
+	if err := createParamVersionWithSecretRef(&buf, projectID, parameterID, parameterVersionID, secretID); err != nil {
 		t.Fatal(err)
 	}
-	defer testCleanupParameter(t, parameter.Name)
-	defer testCleanupParameterVersion(t, fmt.Sprintf("%s/versions/%s", parameter.Name, parameterVersionID))
 
 	if got, want := buf.String(), "Created parameter version with secret reference:"; !strings.Contains(got, want) {
 		t.Errorf("createParameterVersion: expected %q to contain %q", got, want)
 	}
+
+	version := testGetParameterVersion(t, fmt.Sprintf("%s/versions/%s", parameterName, parameterVersionID))
+	if got, want := string(version.Payload.Data), fmt.Sprintf(`__REF__(\"//secretmanager.googleapis.com/%s/versions/latest\")`, secret.Name); !strings.Contains(got, want) {
+		t.Errorf("createParameterVersion: expected payload %q to contain %q", got, want)
+	}
 }
 
 // TestGetParam tests the getParam function by creating a parameter,
 // then attempts to retrieve the created parameter. It verifies if the parameter
 // was successfully retrieved by checking the output.
 func TestGetParam(t *testing.T) {
-	tc := testutil.SystemTest(t)
-
-	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
-	defer testCleanupParameter(t, parameter.Name)
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
 
 	var buf bytes.Buffer
-	if err := getParam(&buf, tc.ProjectID, parameterID); err != nil {
+	if err := getParam(&buf, projectID, parameterID); err != nil {
 		t.Fatal(err)
 	}
 
-	if got, want := buf.String(), fmt.Sprintf("Found parameter %s with format JSON", parameter.Name); !strings.Contains(got, want) {
+	if got, want := buf.String(), fmt.Sprintf("Found parameter %s with format JSON", parameterName); !strings.Contains(got, want) {
+		t.Errorf("GetParameter: expected %q to contain %q", got, want)
+	}
+
+	if got, want := buf.String(), "Google-managed key"; !strings.Contains(got, want) {
 		t.Errorf("GetParameter: expected %q to contain %q", got, want)
 	}
 }
 
+// TestGetParamNotFound tests that the getParam function returns a friendly
+// error when the requested parameter does not exist.
+func TestGetParamNotFound(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	parameterID := testName(t)
+
+	var buf bytes.Buffer
+	err := getParam(&buf, tc.ProjectID, parameterID)
+	if err == nil {
+		t.Fatal("GetParameter: expected error for missing parameter, got nil")
+	}
+
+	if got, want := err.Error(), fmt.Sprintf("parameter %s not found", parameterID); got != want {
+		t.Errorf("GetParameter: expected error %q, got %q", want, got)
+	}
+}
+
 // TestDeleteParam tests the deleteParamVersion function by creating a parameter and
 // parameter version, then attempts to delete the created parameter version. It verifies
 // if the parameter version was successfully deleted by checking the output.
 func TestDeleteParamVersion(t *testing.T) {
-	tc := testutil.SystemTest(t)
-
-	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
 	payload := `{"username": "test-user", "host": "localhost"}`
-	parameterVersion, parameterVersionID := testParameterVersion(t, tc.ProjectID, parameterID, payload)
-	defer testCleanupParameter(t, parameter.Name)
-	defer testCleanupParameterVersion(t, parameterVersion.Name)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+	parameterVersionName := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, parameterVersionID)
 
 	var buf bytes.Buffer
-	if err := deleteParamVersion(&buf, tc.ProjectID, parameterID, parameterVersionID); err != nil {
+	if err := deleteParamVersion(&buf, projectID, parameterID, parameterVersionID); err != nil {
 		t.Fatal(err)
 	}
 
 	if got, want := buf.String(), "Deleted parameter version"; !strings.Contains(got, want) {
 		t.Errorf("DeleteParameterVersion: expected %q to contain %q", got, want)
 	}
+
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: parameterVersionName,
+	})
+	if got, want := grpcstatus.Code(err), grpccodes.NotFound; got != want {
+		t.Errorf("GetParameterVersion: expected code %v after delete, got %v", want, got)
+	}
+
+	// Deleting the version again is a no-op.
+	buf.Reset()
+	if err := deleteParamVersion(&buf, projectID, parameterID, parameterVersionID); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), "not found, nothing to delete"; !strings.Contains(got, want) {
+		t.Errorf("DeleteParameterVersion: expected %q to contain %q", got, want)
+	}
 }
 
-// TestListParam tests the listParam function by creating multiple parameters,
+// TestListParam tests the listParams function by creating multiple parameters,
 // then attempts to list the created parameters. It verifies if the parameters
 // were successfully listed by checking the output.
 func TestListParam(t *testing.T) {
-	tc := testutil.SystemTest(t)
-
-	parameter1, _ := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
-	parameter2, _ := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	projectID := setupTestProject(t)
 
-	defer testCleanupParameter(t, parameter1.Name)
-	defer testCleanupParameter(t, parameter2.Name)
+	var parameters []*parametermanagerpb.Parameter
+	for _, format := range []parametermanagerpb.ParameterFormat{
+		parametermanagerpb.ParameterFormat_JSON,
+		parametermanagerpb.ParameterFormat_UNFORMATTED,
+		parametermanagerpb.ParameterFormat_YAML,
+	} {
+		_, parameterID, _ := setupTestParameter(t, format)
+		parameters = append(parameters, &parametermanagerpb.Parameter{
+			Name:   fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID),
+			Format: format,
+		})
+	}
 
 	var buf bytes.Buffer
-	if err := listParams(&buf, tc.ProjectID); err != nil {
+	if err := listParams(&buf, projectID); err != nil {
 		t.Fatal(err)
 	}
 
-	if got, want := buf.String(), fmt.Sprintf("Found parameter %s with format %s", parameter1.Name, parameter1.Format); !strings.Contains(got, want) {
-		t.Errorf("ListParameter: expected %q to contain %q", got, want)
+	for _, parameter := range parameters {
+		if got, want := buf.String(), fmt.Sprintf("Found parameter %s with format %s", parameter.Name, parameter.Format); !strings.Contains(got, want) {
+			t.Errorf("ListParameter: expected %q to contain %q", got, want)
+		}
 	}
 
-	if got, want := buf.String(), fmt.Sprintf("Found parameter %s with format %s", parameter2.Name, parameter2.Format); !strings.Contains(got, want) {
-		t.Errorf("ListParameter: expected %q to contain %q", got, want)
+	if got, want := buf.String(), "parameters\n"; !strings.HasSuffix(got, want) {
+		t.Errorf("ListParameter: expected %q to end with the parameter count", got)
 	}
 }
 
@@ -548,57 +705,69 @@ func TestListParam(t *testing.T) {
 // then attempts to disable the created parameter version. It verifies if the parameter version
 // was successfully disabled by checking the output.
 func TestDisableParamVersion(t *testing.T) {
-	tc := testutil.SystemTest(t)
-
-	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
 	payload := `{"username": "test-user", "host": "localhost"}`
-	parameterVersion, parameterVersionID := testParameterVersion(t, tc.ProjectID, parameterID, payload)
-	defer testCleanupParameter(t, parameter.Name)
-	defer testCleanupParameterVersion(t, parameterVersion.Name)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+	parameterVersionName := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, parameterVersionID)
 
-	var buf bytes.Buffer
-	if err := disableParamVersion(&buf, tc.ProjectID, parameterID, parameterVersionID); err != nil {
-		t.Fatal(err)
+	// Disabling twice must succeed both times.
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		if err := disableParamVersion(&buf, projectID, parameterID, parameterVersionID); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := buf.String(), "Disabled parameter version"; !strings.Contains(got, want) {
+			t.Errorf("DisableParameterVersion: expected %q to contain %q", got, want)
+		}
+
+		if got, want := buf.String(), "Disabled state: true"; !strings.Contains(got, want) {
+			t.Errorf("DisableParameterVersion: expected %q to contain %q", got, want)
+		}
 	}
 
-	if got, want := buf.String(), "Disabled parameter version"; !strings.Contains(got, want) {
-		t.Errorf("DisableParameterVersion: expected %q to contain %q", got, want)
+	if !testGetParameterVersion(t, parameterVersionName).Disabled {
+		t.Errorf("DisableParameterVersion: expected %s to be disabled", parameterVersionName)
 	}
 }
 
-// TestEnableParamVersion tests the enableParamVersion function by creating a parameter and its version,
-// then attempts to enable the created parameter version. It verifies if the parameter version
-// was successfully enabled by checking the output.
+// TestEnableParamVersion tests the enableParamVersion function by creating and disabling a parameter
+// version, verifying that it cannot be rendered, then enabling it. It verifies if the parameter version
+// was successfully enabled by checking the output and rendering it again.
 func TestEnableParamVersion(t *testing.T) {
-	tc := testutil.SystemTest(t)
-
-	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
 	payload := `{"username": "test-user", "host": "localhost"}`
-	parameterVersion, parameterVersionID := testParameterVersion(t, tc.ProjectID, parameterID, payload)
-	defer testCleanupParameter(t, parameter.Name)
-	defer testCleanupParameterVersion(t, parameterVersion.Name)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+	parameterVersionName := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, parameterVersionID)
+
+	// A disabled version cannot be rendered.
+	testDisableParameterVersion(t, parameterVersionName)
+	if err := renderParamVersion(io.Discard, projectID, parameterID, parameterVersionID); err == nil {
+		t.Fatal("RenderParameterVersion: expected error rendering a disabled version, got nil")
+	}
 
 	var buf bytes.Buffer
-	if err := enableParamVersion(&buf, tc.ProjectID, parameterID, parameterVersionID); err != nil {
+	if err := enableParamVersion(&buf, projectID, parameterID, parameterVersionID); err != nil {
 		t.Fatal(err)
 	}
 
 	if got, want := buf.String(), "Enabled parameter version"; !strings.Contains(got, want) {
 		t.Errorf("EnableParameterVersion: expected %q to contain %q", got, want)
 	}
+
+	if err := renderParamVersion(io.Discard, projectID, parameterID, parameterVersionID); err != nil {
+		t.Errorf("RenderParameterVersion: expected render to succeed after enabling: %v", err)
+	}
 }
 
 // TestDeleteParam tests the deleteParam function by creating a parameter,
 // then attempts to delete the created parameter. It verifies if the parameter
 // was successfully deleted by checking the output.
 func TestDeleteParam(t *testing.T) {
-	tc := testutil.SystemTest(t)
-
-	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
-	defer testCleanupParameter(t, parameter.Name)
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
 
 	var buf bytes.Buffer
-	if err := deleteParam(&buf, tc.ProjectID, parameterID); err != nil {
+	if err := deleteParam(&buf, projectID, parameterID); err != nil {
 		t.Fatal(err)
 	}
 
@@ -607,6 +776,24 @@ func TestDeleteParam(t *testing.T) {
 	}
 }
 
+// TestDeleteParamWithVersions tests that the deleteParam function returns a
+// friendly error when the parameter still has versions.
+func TestDeleteParamWithVersions(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	payload := `{"username": "test-user", "host": "localhost"}`
+	setupTestParameterVersion(t, projectID, parameterID, payload)
+
+	var buf bytes.Buffer
+	err := deleteParam(&buf, projectID, parameterID)
+	if err == nil {
+		t.Fatal("DeleteParameter: expected error for parameter with versions, got nil")
+	}
+
+	if got, want := err.Error(), "delete its versions first"; !strings.Contains(got, want) {
+		t.Errorf("DeleteParameter: expected %q to contain %q", got, want)
+	}
+}
+
 // TestCreateParamWithKmsKey tests the createParamWithKmsKey function by creating a parameter with a KMS key,
 // and verifies if the parameter was successfully created by checking the output.
 func TestCreateParamWithKmsKey(t *testing.T) {
@@ -630,6 +817,10 @@ func TestCreateParamWithKmsKey(t *testing.T) {
 	if got, want := buf.String(), fmt.Sprintf("Created parameter %s with kms_key %s", parameterName, kms_key); !strings.Contains(got, want) {
 		t.Errorf("createParameter: expected %q to contain %q", got, want)
 	}
+
+	if got, want := testGetParameter(t, parameterName).GetKmsKey(), kms_key; got != want {
+		t.Errorf("createParameter: expected kms_key %q, got %q", want, got)
+	}
 }
 
 // TestUpdateParamKmsKey tests the updateParamKmsKey function by creating a parameter with a KMS key,
@@ -656,54 +847,59 @@ func TestUpdateParamKmsKey(t *testing.T) {
 	}
 }
 
-// TestRemoveParamKmsKey tests the removeParamKmsKey function by creating a parameter with a KMS key,
-// removing the KMS key, and verifying if the KMS key was successfully removed by checking the output.
+// TestRemoveParamKmsKey tests the removeParamKmsKey function by setting a KMS key on a parameter
+// with updateParamKmsKey, removing the KMS key, and verifying the parameter no longer has a key.
 func TestRemoveParamKmsKey(t *testing.T) {
-	tc := testutil.SystemTest(t)
+	projectID := setupTestProject(t)
 
-	testCreateKeyRing(t, tc.ProjectID, "go-test-key-ring")
+	testCreateKeyRing(t, projectID, "go-test-key-ring")
 	keyId := testName(t)
-	testCreateKeyHSM(t, tc.ProjectID, "go-test-key-ring", keyId)
-	kms_key := fmt.Sprintf("projects/%s/locations/global/keyRings/go-test-key-ring/cryptoKeys/%s", tc.ProjectID, keyId)
+	testCreateKeyHSM(t, projectID, "go-test-key-ring", keyId)
+	kms_key := fmt.Sprintf("projects/%s/locations/global/keyRings/go-test-key-ring/cryptoKeys/%s", projectID, keyId)
 
-	parameter, parameterID := testParameterWithKmsKey(t, tc.ProjectID, kms_key)
-	defer testCleanupParameter(t, parameter.Name)
+	_, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
 	defer testCleanupKeyVersions(t, fmt.Sprintf("%s/cryptoKeyVersions/1", kms_key))
 
+	if err := updateParamKmsKey(io.Discard, projectID, parameterID, kms_key); err != nil {
+		t.Fatalf("Failed to set kms_key: %v", err)
+	}
+
 	var buf bytes.Buffer
-	if err := removeParamKmsKey(&buf, tc.ProjectID, parameterID); err != nil {
-		t.Fatalf("Failed to create parameter: %v", err)
+	if err := removeParamKmsKey(&buf, projectID, parameterID); err != nil {
+		t.Fatalf("Failed to remove kms_key: %v", err)
 	}
-	if got, want := buf.String(), fmt.Sprintf("Removed kms_key for parameter %s", parameter.Name); !strings.Contains(got, want) {
-		t.Errorf("createParameter: expected %q to contain %q", got, want)
+	if got, want := buf.String(), fmt.Sprintf("Removed kms_key from %s", parameterName); !strings.Contains(got, want) {
+		t.Errorf("removeParamKmsKey: expected %q to contain %q", got, want)
+	}
+
+	if got := testGetParameter(t, parameterName).GetKmsKey(); got != "" {
+		t.Errorf("removeParamKmsKey: expected kms_key to be empty, got %q", got)
 	}
 }
 
 // TestListParamVersions tests the listParamVersion function by creating a parameter and its versions,
-// then attempts to list the created parameter versions. It verifies if the parameter versions
-// were successfully listed by checking the output.
+// disabling one of them, then attempts to list the created parameter versions. It verifies if the
+// parameter versions were successfully listed with their disabled state by checking the output.
 func TestListParamVersion(t *testing.T) {
-	tc := testutil.SystemTest(t)
-
-	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
 	payload := `{"username": "test-user", "host": "localhost"}`
-	parameterVersion1, _ := testParameterVersion(t, tc.ProjectID, parameterID, payload)
-	parameterVersion2, _ := testParameterVersion(t, tc.ProjectID, parameterID, payload)
-
-	defer testCleanupParameter(t, parameter.Name)
-	defer testCleanupParameterVersion(t, parameterVersion1.Name)
-	defer testCleanupParameterVersion(t, parameterVersion2.Name)
+	parameterVersion1ID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+	parameterVersion1Name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, parameterVersion1ID)
+	parameterVersion2ID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+	parameterVersion2Name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, parameterVersion2ID)
+	testDisableParameterVersion(t, parameterVersion2Name)
 
 	var buf bytes.Buffer
-	if err := listParamVersions(&buf, tc.ProjectID, parameterID); err != nil {
+	if err := listParamVersions(&buf, projectID, parameterID); err != nil {
 		t.Fatal(err)
 	}
 
-	if got, want := buf.String(), fmt.Sprintf("Found parameter version %s with disabled state in %v", parameterVersion1.Name, parameterVersion1.Disabled); !strings.Contains(got, want) {
+	if got, want := buf.String(), fmt.Sprintf("Found parameter version %s with disabled state in false", parameterVersion1Name); !strings.Contains(got, want) {
 		t.Errorf("ListParameterVersion: expected %q to contain %q", got, want)
 	}
 
-	if got, want := buf.String(), fmt.Sprintf("Found parameter version %s with disabled state in %v", parameterVersion2.Name, parameterVersion2.Disabled); !strings.Contains(got, want) {
+	if got, want := buf.String(), fmt.Sprintf("Found parameter version %s with disabled state in true", parameterVersion2Name); !strings.Contains(got, want) {
 		t.Errorf("ListParameterVersion: expected %q to contain %q", got, want)
 	}
 }
@@ -712,20 +908,21 @@ func TestListParamVersion(t *testing.T) {
 // then attempts to retrieve the created parameter version. It verifies if the parameter version
 // was successfully retrieved by checking the output.
 func TestGetParamVersion(t *testing.T) {
-	tc := testutil.SystemTest(t)
-
-	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
 	payload := `{"username": "test-user", "host": "localhost"}`
-	parameterVersion, parameterVersionID := testParameterVersion(t, tc.ProjectID, parameterID, payload)
-	defer testCleanupParameter(t, parameter.Name)
-	defer testCleanupParameterVersion(t, parameterVersion.Name)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+	parameterVersionName := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, parameterVersionID)
 
 	var buf bytes.Buffer
-	if err := getParamVersion(&buf, tc.ProjectID, parameterID, parameterVersionID); err != nil {
+	if err := getParamVersion(&buf, projectID, parameterID, parameterVersionID); err != nil {
 		t.Fatal(err)
 	}
 
-	if got, want := buf.String(), fmt.Sprintf("Found parameter version %s with disabled state in %v", parameterVersion.Name, parameterVersion.Disabled); !strings.Contains(got, want) {
+	if got, want := buf.String(), fmt.Sprintf("Found parameter version %s with disabled state in false", parameterVersionName); !strings.Contains(got, want) {
+		t.Errorf("GetParameterVersion: expected %q to contain %q", got, want)
+	}
+
+	if got, want := buf.String(), fmt.Sprintf("Payload length: %d bytes", len(payload)); !strings.Contains(got, want) {
 		t.Errorf("GetParameterVersion: expected %q to contain %q", got, want)
 	}
 }
@@ -734,23 +931,20 @@ func TestGetParamVersion(t *testing.T) {
 // its version, and a secret. It then attempts to render the created parameter version
 // and verifies if the parameter version was successfully rendered by checking the output.
 func TestRenderParamVersion(t *testing.T) {
-	tc := testutil.SystemTest(t)
-
-	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
-	secret := testSecret(t, tc.ProjectID)
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	secret := testSecret(t, projectID)
 	testSecretVersion(t, secret.Name, []byte("very secret data"))
 	payload := fmt.Sprintf(`{"username": "test-user","password": "__REF__(//secretmanager.googleapis.com/%s/versions/latest)"}`, secret.Name)
-	if err := testIamGrantAccess(t, secret.Name, parameter.PolicyMember.IamPolicyUidPrincipal); err != nil {
+	if err := testIamGrantAccess(t, secret.Name, testGetParameter(t, parameterName).PolicyMember.IamPolicyUidPrincipal); err != nil {
 		t.Fatal(err)
 	}
-	parameterVersion, parameterVersionID := testParameterVersion(t, tc.ProjectID, parameterID, payload)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
 
 	defer testCleanupSecret(t, secret.Name)
-	defer testCleanupParameter(t, parameter.Name)
-	defer testCleanupParameterVersion(t, parameterVersion.Name)
 
 	var buf bytes.Buffer
-	if err := renderParamVersion(&buf, tc.ProjectID, parameterID, parameterVersionID); err != nil {
+	if err := renderParamVersion(&buf, projectID, parameterID, parameterVersionID); err != nil {
 		t.Fatal(err)
 	}
 
@@ -762,3 +956,3909 @@ func TestRenderParamVersion(t *testing.T) {
 		t.Errorf("RenderParameterVersion: expected %q to contain %q", got, want)
 	}
 }
+
+// TestRenderParamVersionWithoutSecrets tests the renderParamVersion function with a
+// payload that has no secret references and verifies the rendered payload matches
+// the stored payload, and that a missing version fails with NotFound. It runs against
+// an in-memory server when no project is configured.
+func TestRenderParamVersionWithoutSecrets(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	payload := `{"username": "test-user", "host": "localhost"}`
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_JSON)
+	parameterVersionID := testName(t)
+	setupTestParameterVersionWithClient(t, client, projectID, parameterID, parameterVersionID, payload)
+
+	var buf bytes.Buffer
+	if err := renderParamVersionWithClient(ctx, client, &buf, projectID, parameterID, parameterVersionID); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), fmt.Sprintf("Rendered payload: %s\n", payload); !strings.Contains(got, want) {
+		t.Errorf("RenderParameterVersion: expected %q to contain %q", got, want)
+	}
+
+	err := renderParamVersionWithClient(ctx, client, io.Discard, projectID, parameterID, testName(t))
+	if got, want := grpcstatus.Code(err), grpccodes.NotFound; got != want {
+		t.Errorf("RenderParameterVersion: expected code %v for a missing version, got %v", want, got)
+	}
+}
+
+// TestQuickstart tests the quickstart function by running the full create, version, render, and
+// delete flow and verifies the rendered payload and each cleanup step by checking the output.
+func TestQuickstart(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	parameterID := testName(t)
+	parameterVersionID := testName(t)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", tc.ProjectID, parameterID)
+
+	// Clean up in case the flow fails midway.
+	defer testCleanupParameter(t, parameterName)
+	defer testCleanupParameterVersion(t, fmt.Sprintf("%s/versions/%s", parameterName, parameterVersionID))
+
+	var buf bytes.Buffer
+	if err := quickstart(&buf, tc.ProjectID, parameterID, parameterVersionID); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`Rendered payload: {"username": "test-user", "host": "localhost"}`,
+		fmt.Sprintf("Deleted parameter version: %s/versions/%s", parameterName, parameterVersionID),
+		fmt.Sprintf("Deleted parameter: %s", parameterName),
+	} {
+		if got := buf.String(); !strings.Contains(got, want) {
+			t.Errorf("quickstart: expected %q to contain %q", got, want)
+		}
+	}
+}
+
+// TestCreateParamIfNotExists tests the createParamIfNotExists function by calling it twice with the
+// same parameter ID and verifies the second call reports the existing parameter without an error.
+func TestCreateParamIfNotExists(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	parameterID := testName(t)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", tc.ProjectID, parameterID)
+	defer testCleanupParameter(t, parameterName)
+
+	var buf bytes.Buffer
+	created, err := createParamIfNotExists(&buf, tc.ProjectID, parameterID, parametermanagerpb.ParameterFormat_JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Created parameter"; !strings.Contains(got, want) {
+		t.Errorf("createParamIfNotExists: expected %q to contain %q", got, want)
+	}
+
+	buf.Reset()
+	existing, err := createParamIfNotExists(&buf, tc.ProjectID, parameterID, parametermanagerpb.ParameterFormat_JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "already exists"; !strings.Contains(got, want) {
+		t.Errorf("createParamIfNotExists: expected %q to contain %q", got, want)
+	}
+	if created.Name != existing.Name {
+		t.Errorf("createParamIfNotExists: expected %q, got %q", created.Name, existing.Name)
+	}
+}
+
+// TestUpdateParamFormat tests the updateParamFormat function by changing an UNFORMATTED
+// parameter to JSON and verifies the new format is reported and stored.
+func TestUpdateParamFormat(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	var buf bytes.Buffer
+	if err := updateParamFormat(&buf, projectID, parameterID, parametermanagerpb.ParameterFormat_JSON); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), "format from UNFORMATTED to JSON"; !strings.Contains(got, want) {
+		t.Errorf("updateParamFormat: expected %q to contain %q", got, want)
+	}
+
+	if got, want := testGetParameter(t, parameterName).Format, parametermanagerpb.ParameterFormat_JSON; got != want {
+		t.Errorf("updateParamFormat: expected format %v, got %v", want, got)
+	}
+}
+
+// TestUpdateParamFormatNonConformingVersion tests the updateParamFormat function on a parameter
+// whose existing version is not valid JSON. Either the update is rejected with an explanatory
+// error, or it succeeds and the existing version is left untouched.
+func TestUpdateParamFormatNonConformingVersion(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	payload := "not json"
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+	parameterVersionName := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, parameterVersionID)
+
+	var buf bytes.Buffer
+	if err := updateParamFormat(&buf, projectID, parameterID, parametermanagerpb.ParameterFormat_JSON); err != nil {
+		if got, want := err.Error(), "may not conform"; !strings.Contains(got, want) {
+			t.Errorf("updateParamFormat: expected %q to contain %q", got, want)
+		}
+		return
+	}
+
+	if got := string(testGetParameterVersion(t, parameterVersionName).Payload.Data); got != payload {
+		t.Errorf("updateParamFormat: expected existing payload %q to be unchanged, got %q", payload, got)
+	}
+}
+
+// TestRenderParamVersionJSON tests the RenderParamVersionJSON function by rendering a JSON
+// parameter version into a typed struct and verifies the fields are populated.
+func TestRenderParamVersionJSON(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"DBHost": "db.example.com", "Port": 5432}`)
+
+	type config struct {
+		DBHost string
+		Port   int
+	}
+
+	got, err := RenderParamVersionJSON[config](context.Background(), projectID, parameterID, parameterVersionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (config{DBHost: "db.example.com", Port: 5432}); got != want {
+		t.Errorf("RenderParamVersionJSON: expected %+v, got %+v", want, got)
+	}
+}
+
+// TestRenderParamVersionJSONMalformed tests the RenderParamVersionJSON function with a payload
+// that is not valid JSON and verifies the error reports where decoding failed.
+func TestRenderParamVersionJSONMalformed(t *testing.T) {
+	// JSON parameters reject malformed payloads, so store it in an UNFORMATTED parameter.
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, "{\n  \"DBHost\": \"db.example.com\",\n  \"Port\": oops\n}")
+
+	type config struct {
+		DBHost string
+		Port   int
+	}
+
+	_, err := RenderParamVersionJSON[config](context.Background(), projectID, parameterID, parameterVersionID)
+	if err == nil {
+		t.Fatal("RenderParamVersionJSON: expected an error for a malformed payload")
+	}
+
+	if got, want := err.Error(), "line 3"; !strings.Contains(got, want) {
+		t.Errorf("RenderParamVersionJSON: expected %q to contain %q", got, want)
+	}
+}
+
+// TestWaitForRenderable tests the waitForRenderable function with a version that renders
+// immediately and verifies it returns without an error.
+func TestWaitForRenderable(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"username": "test-user"}`)
+
+	if err := waitForRenderable(context.Background(), projectID, parameterID, parameterVersionID, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestWaitForRenderableCancelled tests the waitForRenderable function with a context that is
+// already cancelled and verifies it returns the context error without polling.
+func TestWaitForRenderableCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := waitForRenderable(ctx, "project", "parameter", "version", time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("waitForRenderable: expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waitForRenderable: expected an immediate return, took %s", elapsed)
+	}
+}
+
+// TestGrantRenderAccess tests the grantRenderAccess function by granting a service account
+// access to a secret and verifies the member appears in the secret's IAM policy.
+func TestGrantRenderAccess(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	serviceAccount := os.Getenv("GOLANG_SAMPLES_SERVICE_ACCOUNT_EMAIL")
+	if serviceAccount == "" {
+		t.Skip("GOLANG_SAMPLES_SERVICE_ACCOUNT_EMAIL not set")
+	}
+
+	secret := testSecret(t, tc.ProjectID)
+	defer testCleanupSecret(t, secret.Name)
+	secretID := secret.Name[strings.LastIndex(secret.Name, "/")+1:]
+
+	var buf bytes.Buffer
+	if err := grantRenderAccess(&buf, tc.ProjectID, secretID, serviceAccount); err != nil {
+		t.Fatal(err)
+	}
+
+	member := "serviceAccount:" + serviceAccount
+	if got := buf.String(); !strings.Contains(got, member) {
+		t.Errorf("grantRenderAccess: expected %q to contain %q", got, member)
+	}
+
+	ctx := context.Background()
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	policy, err := client.IAM(secret.Name).Policy(ctx)
+	if err != nil {
+		t.Fatalf("failed to get policy: %v", err)
+	}
+	if !policy.HasRole(member, "roles/secretmanager.secretAccessor") {
+		t.Errorf("grantRenderAccess: expected %s to have roles/secretmanager.secretAccessor on %s", member, secret.Name)
+	}
+}
+
+// TestBulkCreateVersions tests the bulkCreateVersions function by creating 20 versions
+// concurrently and verifies all of them exist afterwards.
+func TestBulkCreateVersions(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	versions := make(map[string][]byte)
+	for i := 0; i < 20; i++ {
+		versions[testName(t)] = []byte(fmt.Sprintf(`{"index": %d}`, i))
+	}
+
+	created, err := bulkCreateVersions(context.Background(), projectID, parameterID, versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != len(versions) {
+		t.Errorf("bulkCreateVersions: expected %d versions created, got %d", len(versions), created)
+	}
+
+	for versionID, payload := range versions {
+		version := testGetParameterVersion(t, fmt.Sprintf("%s/versions/%s", parameterName, versionID))
+		if got := string(version.Payload.Data); got != string(payload) {
+			t.Errorf("bulkCreateVersions: expected version %s payload %q, got %q", versionID, payload, got)
+		}
+	}
+}
+
+// TestWithRetry tests the withRetry function with an operation that fails with Unavailable
+// twice and then succeeds, and verifies it is called exactly three times.
+func TestWithRetry(t *testing.T) {
+	cfg := defaultRetryConfig()
+	cfg.BaseDelay = time.Millisecond
+
+	calls := 0
+	op := func() error {
+		calls++
+		if calls <= 2 {
+			return grpcstatus.Error(grpccodes.Unavailable, "unavailable")
+		}
+		return nil
+	}
+
+	if err := withRetry(context.Background(), cfg, op); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Errorf("withRetry: expected 3 calls, got %d", calls)
+	}
+}
+
+// TestWithRetryNonRetryable tests the withRetry function with an operation that fails with
+// a non-retryable code and verifies it is returned after a single call.
+func TestWithRetryNonRetryable(t *testing.T) {
+	calls := 0
+	op := func() error {
+		calls++
+		return grpcstatus.Error(grpccodes.InvalidArgument, "invalid")
+	}
+
+	err := withRetry(context.Background(), defaultRetryConfig(), op)
+	if got, want := grpcstatus.Code(err), grpccodes.InvalidArgument; got != want {
+		t.Errorf("withRetry: expected code %v, got %v", want, got)
+	}
+	if calls != 1 {
+		t.Errorf("withRetry: expected 1 call, got %d", calls)
+	}
+}
+
+// TestWithRetryMaxAttempts tests the withRetry function with an operation that always fails
+// with Unavailable and verifies it gives up after the attempts set in its config.
+func TestWithRetryMaxAttempts(t *testing.T) {
+	cfg := retryConfig{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	calls := 0
+	op := func() error {
+		calls++
+		return grpcstatus.Error(grpccodes.Unavailable, "unavailable")
+	}
+
+	err := withRetry(context.Background(), cfg, op)
+	if got, want := grpcstatus.Code(err), grpccodes.Unavailable; got != want {
+		t.Errorf("withRetry: expected code %v, got %v", want, got)
+	}
+	if calls != 2 {
+		t.Errorf("withRetry: expected 2 calls, got %d", calls)
+	}
+}
+
+// TestWithDeadlineRetry tests the withDeadlineRetry function with a 100ms deadline and an
+// operation that always fails with Unavailable, and verifies it gives up close to the
+// deadline with the Unavailable error rather than the context error.
+func TestWithDeadlineRetry(t *testing.T) {
+	const budget = 100 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), budget)
+	defer cancel()
+
+	calls := 0
+	op := func(ctx context.Context) error {
+		calls++
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("withDeadlineRetry: expected each attempt to have a deadline")
+		}
+		return grpcstatus.Error(grpccodes.Unavailable, "unavailable")
+	}
+
+	start := time.Now()
+	err := withDeadlineRetry(ctx, op)
+	elapsed := time.Since(start)
+
+	if got, want := grpcstatus.Code(err), grpccodes.Unavailable; got != want {
+		t.Errorf("withDeadlineRetry: expected code %v, got %v (%v)", want, got, err)
+	}
+	if min, max := budget-2*deadlineRetryMinAttempt, budget+50*time.Millisecond; elapsed < min || elapsed > max {
+		t.Errorf("withDeadlineRetry: expected to return between %v and %v, took %v", min, max, elapsed)
+	}
+	if calls < 2 {
+		t.Errorf("withDeadlineRetry: expected at least 2 attempts, got %d", calls)
+	}
+}
+
+// TestListParamsByLabel tests the listParamsByLabel function by creating two parameters with
+// different label values and verifies the filter returns only the matching one.
+func TestListParamsByLabel(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	value := testName(t)
+	matching, _ := testParameterWithLabels(t, tc.ProjectID, map[string]string{"team": value})
+	defer testCleanupParameter(t, matching.Name)
+	other, _ := testParameterWithLabels(t, tc.ProjectID, map[string]string{"team": "other-" + value})
+	defer testCleanupParameter(t, other.Name)
+
+	var buf bytes.Buffer
+	if err := listParamsByLabel(&buf, tc.ProjectID, "team", value); err != nil {
+		t.Fatal(err)
+	}
+
+	got := buf.String()
+	if want := matching.Name; !strings.Contains(got, want) {
+		t.Errorf("listParamsByLabel: expected %q to contain %q", got, want)
+	}
+	if notWant := other.Name; strings.Contains(got, notWant) {
+		t.Errorf("listParamsByLabel: expected %q to not contain %q", got, notWant)
+	}
+	if want := "Found 1 parameters"; !strings.Contains(got, want) {
+		t.Errorf("listParamsByLabel: expected %q to contain %q", got, want)
+	}
+}
+
+// TestCreateParamWithLabels tests the createParamWithLabels function by creating a parameter
+// with two labels and verifies GetParameter returns them.
+func TestCreateParamWithLabels(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	parameterID := testName(t)
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", tc.ProjectID, parameterID)
+	labels := map[string]string{"team": "payments", "env": "test"}
+
+	var buf bytes.Buffer
+	if err := createParamWithLabels(&buf, tc.ProjectID, parameterID, labels); err != nil {
+		t.Fatal(err)
+	}
+	defer testCleanupParameter(t, name)
+
+	if got, want := buf.String(), "with 2 labels"; !strings.Contains(got, want) {
+		t.Errorf("createParamWithLabels: expected %q to contain %q", got, want)
+	}
+
+	got := testGetParameter(t, name).Labels
+	if len(got) != len(labels) {
+		t.Fatalf("createParamWithLabels: expected labels %v, got %v", labels, got)
+	}
+	for key, value := range labels {
+		if got[key] != value {
+			t.Errorf("createParamWithLabels: expected label %s=%s, got %s=%s", key, value, key, got[key])
+		}
+	}
+}
+
+// TestCreateParamWithLabelsInvalid tests the createParamWithLabels function with labels that
+// violate GCP's constraints and verifies an error is returned before calling the API.
+func TestCreateParamWithLabelsInvalid(t *testing.T) {
+	for _, labels := range []map[string]string{
+		{"Team": "payments"},
+		{"team": "Payments"},
+		{"1team": "payments"},
+		{strings.Repeat("k", 64): "payments"},
+		{"team": strings.Repeat("v", 64)},
+	} {
+		var buf bytes.Buffer
+		err := createParamWithLabels(&buf, "project", "parameter", labels)
+		if err == nil {
+			t.Errorf("createParamWithLabels(%v): expected an error", labels)
+			continue
+		}
+		if got, want := err.Error(), "invalid"; !strings.Contains(got, want) {
+			t.Errorf("createParamWithLabels(%v): expected %q to contain %q", labels, got, want)
+		}
+	}
+}
+
+// TestUpdateParamLabels tests the updateParamLabels function in replace and merge modes
+// and verifies the stored labels match the expected label map.
+func TestUpdateParamLabels(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	for _, tt := range []struct {
+		merge bool
+		want  map[string]string
+	}{
+		{merge: false, want: map[string]string{"env": "prod"}},
+		{merge: true, want: map[string]string{"team": "payments", "env": "prod"}},
+	} {
+		parameter, parameterID := testParameterWithLabels(t, tc.ProjectID, map[string]string{"team": "payments", "env": "test"})
+		defer testCleanupParameter(t, parameter.Name)
+
+		var buf bytes.Buffer
+		if err := updateParamLabels(&buf, tc.ProjectID, parameterID, map[string]string{"env": "prod"}, tt.merge); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := buf.String(), "Label env=prod"; !strings.Contains(got, want) {
+			t.Errorf("updateParamLabels(merge=%v): expected %q to contain %q", tt.merge, got, want)
+		}
+
+		got := testGetParameter(t, parameter.Name).Labels
+		if len(got) != len(tt.want) {
+			t.Errorf("updateParamLabels(merge=%v): expected labels %v, got %v", tt.merge, tt.want, got)
+			continue
+		}
+		for key, value := range tt.want {
+			if got[key] != value {
+				t.Errorf("updateParamLabels(merge=%v): expected labels %v, got %v", tt.merge, tt.want, got)
+				break
+			}
+		}
+	}
+}
+
+// TestExportParam tests the exportParam function by exporting a parameter with two versions
+// to a temporary file and verifies the file parses and contains both versions.
+func TestExportParam(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	versionAID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"version": "a"}`)
+	versionBID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"version": "b"}`)
+
+	outputPath := filepath.Join(t.TempDir(), "export.json")
+
+	var buf bytes.Buffer
+	if err := exportParam(&buf, projectID, parameterID, outputPath); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), "Exported 2 parameter versions"; !strings.Contains(got, want) {
+		t.Errorf("exportParam: expected %q to contain %q", got, want)
+	}
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var export paramExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("exportParam: failed to parse export: %v", err)
+	}
+
+	if got, want := export.Format, "JSON"; got != want {
+		t.Errorf("exportParam: expected format %q, got %q", want, got)
+	}
+	for versionID, payload := range map[string]string{versionAID: `{"version": "a"}`, versionBID: `{"version": "b"}`} {
+		version, ok := export.Versions[versionID]
+		if !ok {
+			t.Errorf("exportParam: expected export to contain version %s", versionID)
+			continue
+		}
+		if got := string(version.Payload); got != payload {
+			t.Errorf("exportParam: expected version %s payload %q, got %q", versionID, payload, got)
+		}
+	}
+}
+
+// TestImportParam tests the importParam function by exporting a parameter and importing it
+// into a new parameter ID, and verifies the imported payloads match the originals.
+func TestImportParam(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	versionID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"version": "a"}`)
+
+	inputPath := filepath.Join(t.TempDir(), "export.json")
+	if err := exportParam(io.Discard, projectID, parameterID, inputPath); err != nil {
+		t.Fatal(err)
+	}
+
+	importedID := testName(t)
+	importedName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, importedID)
+	importedVersionName := fmt.Sprintf("%s/versions/%s", importedName, versionID)
+
+	var buf bytes.Buffer
+	if err := importParam(&buf, projectID, importedID, inputPath); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { testDeleteParameterTree(t, importedName) })
+
+	if got, want := buf.String(), "Imported 1 parameter versions"; !strings.Contains(got, want) {
+		t.Errorf("importParam: expected %q to contain %q", got, want)
+	}
+
+	if got, want := string(testGetParameterVersion(t, importedVersionName).Payload.Data), `{"version": "a"}`; got != want {
+		t.Errorf("importParam: expected payload %q, got %q", want, got)
+	}
+
+	// Importing again skips the versions that already exist.
+	buf.Reset()
+	if err := importParam(&buf, projectID, importedID, inputPath); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "already exists, skipping"; !strings.Contains(got, want) {
+		t.Errorf("importParam: expected %q to contain %q", got, want)
+	}
+}
+
+// TestDiffParamVersions tests the diffParamVersions function with two JSON versions that
+// differ in one field and list their keys in a different order, and verifies only that
+// field is flagged as changed.
+func TestDiffParamVersions(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	versionAID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"host": "db.example.com", "port": 5432}`)
+	versionBID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"port": 6543, "host": "db.example.com"}`)
+
+	var buf bytes.Buffer
+	if err := diffParamVersions(&buf, projectID, parameterID, versionAID, versionBID); err != nil {
+		t.Fatal(err)
+	}
+
+	var changed []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if (strings.HasPrefix(line, "-") || strings.HasPrefix(line, "+")) &&
+			!strings.HasPrefix(line, "---") && !strings.HasPrefix(line, "+++") {
+			changed = append(changed, line)
+		}
+	}
+
+	if want := []string{`-  "port": 5432`, `+  "port": 6543`}; strings.Join(changed, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diffParamVersions: expected changed lines %q, got %q", want, changed)
+	}
+}
+
+// TestDiffLines tests the diffLines function with a single changed line in the middle of
+// a long payload, and verifies only the lines near the change are included in the hunk.
+func TestDiffLines(t *testing.T) {
+	var a, b []string
+	for i := 1; i <= 20; i++ {
+		a = append(a, fmt.Sprintf("line %d", i))
+		b = append(b, fmt.Sprintf("line %d", i))
+	}
+	b[9] = "line ten"
+
+	want := []string{
+		"@@ -7,7 +7,7 @@",
+		" line 7",
+		" line 8",
+		" line 9",
+		"-line 10",
+		"+line ten",
+		" line 11",
+		" line 12",
+		" line 13",
+	}
+	if got := diffLines(a, b); !slices.Equal(got, want) {
+		t.Errorf("diffLines: expected %q, got %q", want, got)
+	}
+
+	if got := diffLines(a, a); len(got) != 0 {
+		t.Errorf("diffLines: expected no hunks for equal input, got %q", got)
+	}
+}
+
+// TestCopyParam tests the copyParam function by copying a parameter with one version to a
+// second project and verifies the format and payload are copied. It is skipped unless
+// GOLANG_SAMPLES_SECONDARY_PROJECT_ID names the destination project.
+func TestCopyParam(t *testing.T) {
+	dstProjectID := os.Getenv("GOLANG_SAMPLES_SECONDARY_PROJECT_ID")
+	if dstProjectID == "" {
+		t.Skip("GOLANG_SAMPLES_SECONDARY_PROJECT_ID not set")
+	}
+
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	versionID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"username": "test-user"}`)
+
+	dstName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", dstProjectID, parameterID)
+	dstVersionName := fmt.Sprintf("%s/versions/%s", dstName, versionID)
+
+	var buf bytes.Buffer
+	if err := copyParam(&buf, projectID, dstProjectID, parameterID); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { testDeleteParameterTree(t, dstName) })
+
+	if got, want := buf.String(), "with 1 versions"; !strings.Contains(got, want) {
+		t.Errorf("copyParam: expected %q to contain %q", got, want)
+	}
+
+	if got, want := testGetParameter(t, dstName).Format, parametermanagerpb.ParameterFormat_JSON; got != want {
+		t.Errorf("copyParam: expected format %v, got %v", want, got)
+	}
+	if got, want := string(testGetParameterVersion(t, dstVersionName).Payload.Data), `{"username": "test-user"}`; got != want {
+		t.Errorf("copyParam: expected payload %q, got %q", want, got)
+	}
+}
+
+// TestDeleteParamsByPrefix tests the deleteParamsByPrefix function by creating three
+// parameters sharing a prefix, verifies a dry run deletes none of them, and then verifies
+// a real run deletes all of them.
+func TestDeleteParamsByPrefix(t *testing.T) {
+	projectID := setupTestProject(t)
+
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	prefix := "prefix-" + testName(t)[:8] + "-"
+	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
+	var names []string
+	for i := 0; i < 3; i++ {
+		parameter, err := client.CreateParameter(ctx, &parametermanagerpb.CreateParameterRequest{
+			Parent:      parent,
+			ParameterId: fmt.Sprintf("%s%d", prefix, i),
+			Parameter: &parametermanagerpb.Parameter{
+				Format: parametermanagerpb.ParameterFormat_UNFORMATTED,
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to create parameter: %v", err)
+		}
+		t.Cleanup(func() { testDeleteParameterTree(t, parameter.Name) })
+		names = append(names, parameter.Name)
+	}
+	setupTestParameterVersion(t, projectID, prefix+"0", "payload")
+
+	var buf bytes.Buffer
+	if err := deleteParamsByPrefix(&buf, projectID, prefix, true); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Would delete 3 parameters"; !strings.Contains(got, want) {
+		t.Errorf("deleteParamsByPrefix: expected %q to contain %q", got, want)
+	}
+	for _, name := range names {
+		testGetParameter(t, name)
+	}
+
+	buf.Reset()
+	if err := deleteParamsByPrefix(&buf, projectID, prefix, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Deleted 3 parameters"; !strings.Contains(got, want) {
+		t.Errorf("deleteParamsByPrefix: expected %q to contain %q", got, want)
+	}
+	for _, name := range names {
+		_, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{Name: name})
+		if got, want := grpcstatus.Code(err), grpccodes.NotFound; got != want {
+			t.Errorf("deleteParamsByPrefix: expected %s to be deleted, got code %v", name, got)
+		}
+	}
+}
+
+// TestGetLatestEnabledVersion tests the getLatestEnabledVersion function by creating three
+// versions and disabling the newest, and verifies the second newest is returned.
+func TestGetLatestEnabledVersion(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+
+	var versionNames []string
+	for i := 0; i < 3; i++ {
+		versionID, _ := setupTestParameterVersion(t, projectID, parameterID, fmt.Sprintf("payload-%d", i))
+		versionNames = append(versionNames, fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID))
+	}
+	testDisableParameterVersion(t, versionNames[2])
+
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	got, err := getLatestEnabledVersion(ctx, client, projectID, parameterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := versionNames[1]; got.Name != want {
+		t.Errorf("getLatestEnabledVersion: expected %s, got %s", want, got.Name)
+	}
+}
+
+// TestGetLatestEnabledVersionNone tests the getLatestEnabledVersion function on a parameter
+// without versions and verifies a descriptive error is returned.
+func TestGetLatestEnabledVersionNone(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	_, err = getLatestEnabledVersion(ctx, client, projectID, parameterID)
+	if err == nil {
+		t.Fatal("getLatestEnabledVersion: expected an error")
+	}
+	if !errors.Is(err, errNoEnabledVersions) {
+		t.Errorf("getLatestEnabledVersion: expected errNoEnabledVersions, got %v", err)
+	}
+	if got, want := err.Error(), "has no enabled versions"; !strings.Contains(got, want) {
+		t.Errorf("getLatestEnabledVersion: expected %q to contain %q", got, want)
+	}
+}
+
+// TestRollbackParam tests the rollbackParam function by rolling back to the first of two
+// versions as a third version, and verifies the third version's payload equals the first's.
+// Rolling back again to the same new version ID must fail with AlreadyExists.
+func TestRollbackParam(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	v1ID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"value": 1}`)
+	setupTestParameterVersion(t, projectID, parameterID, `{"value": 2}`)
+	v3ID := testName(t)
+	v3Name := fmt.Sprintf("%s/versions/%s", parameterName, v3ID)
+
+	var buf bytes.Buffer
+	if err := rollbackParam(&buf, projectID, parameterID, v1ID, v3ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), v3Name; !strings.Contains(got, want) {
+		t.Errorf("rollbackParam: expected %q to contain %q", got, want)
+	}
+	if got, want := string(testGetParameterVersion(t, v3Name).Payload.Data), `{"value": 1}`; got != want {
+		t.Errorf("rollbackParam: expected payload %q, got %q", want, got)
+	}
+
+	err := rollbackParam(io.Discard, projectID, parameterID, v1ID, v3ID)
+	if got, want := grpcstatus.Code(err), grpccodes.AlreadyExists; got != want {
+		t.Errorf("rollbackParam: expected code %v, got %v (%v)", want, got, err)
+	}
+}
+
+// TestCreateParamWithLogger tests the createParamWithLogger function with a JSON slog handler
+// and verifies the log line carries the operation and resource_name fields.
+func TestCreateParamWithLogger(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	parameterID := testName(t)
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", tc.ProjectID, parameterID)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	if err := createParamWithLogger(context.Background(), logger, tc.ProjectID, parameterID); err != nil {
+		t.Fatal(err)
+	}
+	defer testCleanupParameter(t, name)
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("createParamWithLogger: failed to parse log line %q: %v", buf.String(), err)
+	}
+	if got, want := record["operation"], "CreateParameter"; got != want {
+		t.Errorf("createParamWithLogger: expected operation %q, got %v", want, got)
+	}
+	if got, want := record["resource_name"], name; got != want {
+		t.Errorf("createParamWithLogger: expected resource_name %q, got %v", want, got)
+	}
+	if got, want := record["format"], "UNFORMATTED"; got != want {
+		t.Errorf("createParamWithLogger: expected format %q, got %v", want, got)
+	}
+}
+
+// TestTracedRender tests the tracedRender function with a span recorder and verifies one
+// span with the parameter attribute is recorded on success, and that the span is marked
+// as an error with the gRPC code when the version does not exist.
+func TestTracedRender(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"username": "test-user"}`)
+
+	for _, tt := range []struct {
+		versionID string
+		wantErr   bool
+	}{
+		{versionID: parameterVersionID},
+		{versionID: testName(t), wantErr: true},
+	} {
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		tracer := provider.Tracer("parametermanager")
+
+		payload, err := tracedRender(context.Background(), tracer, projectID, parameterID, tt.versionID)
+		if tt.wantErr != (err != nil) {
+			t.Fatalf("tracedRender(%s): expected error %v, got %v", tt.versionID, tt.wantErr, err)
+		}
+		if !tt.wantErr && string(payload) != `{"username": "test-user"}` {
+			t.Errorf("tracedRender(%s): unexpected payload %q", tt.versionID, payload)
+		}
+
+		spans := recorder.Ended()
+		if len(spans) != 1 {
+			t.Fatalf("tracedRender(%s): expected 1 span, got %d", tt.versionID, len(spans))
+		}
+		span := spans[0]
+		if got, want := span.Name(), "parametermanager.RenderParameterVersion"; got != want {
+			t.Errorf("tracedRender(%s): expected span name %q, got %q", tt.versionID, want, got)
+		}
+
+		attrs := make(map[string]string)
+		for _, kv := range span.Attributes() {
+			attrs[string(kv.Key)] = kv.Value.Emit()
+		}
+		if got, want := attrs["parametermanager.parameter"], parameterName; got != want {
+			t.Errorf("tracedRender(%s): expected parameter attribute %q, got %q", tt.versionID, want, got)
+		}
+
+		if tt.wantErr {
+			if got, want := span.Status().Code, otelcodes.Error; got != want {
+				t.Errorf("tracedRender(%s): expected span status %v, got %v", tt.versionID, want, got)
+			}
+			if got, want := attrs["rpc.grpc.status_code"], grpccodes.NotFound.String(); got != want {
+				t.Errorf("tracedRender(%s): expected status code attribute %q, got %q", tt.versionID, want, got)
+			}
+		}
+	}
+}
+
+// TestCountParams tests the countParams function by creating three parameters and verifies
+// they are included in the count, then verifies a cancelled context stops the count.
+func TestCountParams(t *testing.T) {
+	projectID := setupTestProject(t)
+
+	for i := 0; i < 3; i++ {
+		setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	}
+
+	// Other tests may create parameters in the same project concurrently, so the
+	// count can only be bounded from below.
+	count, err := countParams(context.Background(), projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count < 3 {
+		t.Errorf("countParams: expected at least 3 parameters, got %d", count)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := countParams(ctx, projectID); !errors.Is(err, context.Canceled) {
+		t.Errorf("countParams: expected context.Canceled, got %v", err)
+	}
+}
+
+// TestParamExists tests the paramExists function and verifies it reports true for a created
+// parameter and false for a random ID.
+func TestParamExists(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+
+	ctx := context.Background()
+	for id, want := range map[string]bool{parameterID: true, testName(t): false} {
+		got, err := paramExists(ctx, projectID, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("paramExists(%s): expected %v, got %v", id, want, got)
+		}
+	}
+}
+
+// TestParseParameterName tests the ParseParameterName function with global and regional
+// names and with malformed inputs.
+func TestParseParameterName(t *testing.T) {
+	for _, tt := range []struct {
+		name                         string
+		project, location, parameter string
+		wantErr                      bool
+	}{
+		{name: "projects/p/locations/global/parameters/db", project: "p", location: "global", parameter: "db"},
+		{name: "projects/p/locations/us-central1/parameters/db", project: "p", location: "us-central1", parameter: "db"},
+		{name: "", wantErr: true},
+		{name: "projects/p/locations/global/parameters/", wantErr: true},
+		{name: "projects/p/parameters/db", wantErr: true},
+		{name: "projects/p/locations/global/parameters/db/versions/v1", wantErr: true},
+		{name: "/projects/p/locations/global/parameters/db", wantErr: true},
+	} {
+		project, location, parameter, err := ParseParameterName(tt.name)
+		if tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), "invalid parameter name") {
+				t.Errorf("ParseParameterName(%q): expected an invalid parameter name error, got %v", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseParameterName(%q): %v", tt.name, err)
+			continue
+		}
+		if project != tt.project || location != tt.location || parameter != tt.parameter {
+			t.Errorf("ParseParameterName(%q): expected (%q, %q, %q), got (%q, %q, %q)",
+				tt.name, tt.project, tt.location, tt.parameter, project, location, parameter)
+		}
+	}
+}
+
+// TestParseVersionName tests the ParseVersionName function with global and regional
+// version names and with malformed inputs.
+func TestParseVersionName(t *testing.T) {
+	for _, tt := range []struct {
+		name                                  string
+		project, location, parameter, version string
+		wantErr                               bool
+	}{
+		{name: "projects/p/locations/global/parameters/db/versions/v1", project: "p", location: "global", parameter: "db", version: "v1"},
+		{name: "projects/p/locations/europe-west1/parameters/db/versions/v2", project: "p", location: "europe-west1", parameter: "db", version: "v2"},
+		{name: "projects/p/locations/global/parameters/db", wantErr: true},
+		{name: "projects/p/locations/global/parameters/db/versions/", wantErr: true},
+		{name: "projects/p/locations/global/parameters/db/versions/v1/extra", wantErr: true},
+	} {
+		project, location, parameter, version, err := ParseVersionName(tt.name)
+		if tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), "invalid parameter version name") {
+				t.Errorf("ParseVersionName(%q): expected an invalid parameter version name error, got %v", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseVersionName(%q): %v", tt.name, err)
+			continue
+		}
+		if project != tt.project || location != tt.location || parameter != tt.parameter || version != tt.version {
+			t.Errorf("ParseVersionName(%q): expected (%q, %q, %q, %q), got (%q, %q, %q, %q)",
+				tt.name, tt.project, tt.location, tt.parameter, tt.version, project, location, parameter, version)
+		}
+	}
+}
+
+// TestRenderParamAsMap tests the renderParamAsMap function with a JSON version containing
+// nested objects and verifies the nested keys are present in the map.
+func TestRenderParamAsMap(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"db": {"host": "db.example.com", "pool": {"size": 10}}}`)
+
+	got, err := renderParamAsMap(context.Background(), projectID, parameterID, parameterVersionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, ok := got["db"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("renderParamAsMap: expected db to be an object, got %#v", got["db"])
+	}
+	if got, want := db["host"], "db.example.com"; got != want {
+		t.Errorf("renderParamAsMap: expected db.host %q, got %v", want, got)
+	}
+	pool, ok := db["pool"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("renderParamAsMap: expected db.pool to be an object, got %#v", db["pool"])
+	}
+	if got, want := pool["size"], 10.0; got != want {
+		t.Errorf("renderParamAsMap: expected db.pool.size %v, got %v", want, got)
+	}
+}
+
+// TestRenderParamAsMapNotJSON tests the renderParamAsMap function on a YAML parameter and
+// verifies the error explains that the parameter is not JSON.
+func TestRenderParamAsMapNotJSON(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_YAML)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, "db: example")
+
+	_, err := renderParamAsMap(context.Background(), projectID, parameterID, parameterVersionID)
+	if err == nil {
+		t.Fatal("renderParamAsMap: expected an error for a YAML parameter")
+	}
+	if got, want := err.Error(), "not JSON"; !strings.Contains(got, want) {
+		t.Errorf("renderParamAsMap: expected %q to contain %q", got, want)
+	}
+}
+
+// TestRenderParamVersionYAML tests the renderParamVersionYAML function by rendering a YAML
+// version into a typed struct and verifies the fields are populated.
+func TestRenderParamVersionYAML(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_YAML)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, "db_host: db.example.com\nport: 5432\n")
+
+	type config struct {
+		DBHost string `yaml:"db_host"`
+		Port   int    `yaml:"port"`
+	}
+
+	got, fromJSON, err := renderParamVersionYAML[config](context.Background(), projectID, parameterID, parameterVersionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromJSON {
+		t.Error("renderParamVersionYAML: expected a YAML parameter not to be reported as JSON")
+	}
+	if want := (config{DBHost: "db.example.com", Port: 5432}); got != want {
+		t.Errorf("renderParamVersionYAML: expected %+v, got %+v", want, got)
+	}
+}
+
+// TestRenderParamVersionYAMLFromJSON tests the renderParamVersionYAML function on a JSON
+// parameter and verifies the value is decoded and the parameter is reported as JSON.
+func TestRenderParamVersionYAMLFromJSON(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"db_host": "db.example.com", "port": 5432}`)
+
+	type config struct {
+		DBHost string `yaml:"db_host"`
+		Port   int    `yaml:"port"`
+	}
+
+	got, fromJSON, err := renderParamVersionYAML[config](context.Background(), projectID, parameterID, parameterVersionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fromJSON {
+		t.Error("renderParamVersionYAML: expected the parameter to be reported as JSON")
+	}
+	if want := (config{DBHost: "db.example.com", Port: 5432}); got != want {
+		t.Errorf("renderParamVersionYAML: expected %+v, got %+v", want, got)
+	}
+}
+
+// TestCreateJSONVersionValidated tests the createJSONVersionValidated function with a valid
+// payload and verifies the version is created.
+func TestCreateJSONVersionValidated(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	versionID := testName(t)
+	versionName := fmt.Sprintf("%s/versions/%s", parameterName, versionID)
+
+	var buf bytes.Buffer
+	if err := createJSONVersionValidated(&buf, projectID, parameterID, versionID, []byte(`{"username": "test-user"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), versionName; !strings.Contains(got, want) {
+		t.Errorf("createJSONVersionValidated: expected %q to contain %q", got, want)
+	}
+}
+
+// TestCreateYAMLVersionValidated tests the createYAMLVersionValidated function with a valid
+// payload and verifies the version is created, then verifies a multi-document payload is
+// rejected with a descriptive error.
+func TestCreateYAMLVersionValidated(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_YAML)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	versionID := testName(t)
+	versionName := fmt.Sprintf("%s/versions/%s", parameterName, versionID)
+
+	var buf bytes.Buffer
+	if err := createYAMLVersionValidated(&buf, projectID, parameterID, versionID, []byte("username: test-user\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), versionName; !strings.Contains(got, want) {
+		t.Errorf("createYAMLVersionValidated: expected %q to contain %q", got, want)
+	}
+
+	err := createYAMLVersionValidated(&buf, projectID, parameterID, testName(t), []byte("a: 1\n---\nb: 2\n"))
+	if got, want := fmt.Sprint(err), "only a single document is allowed"; !strings.Contains(got, want) {
+		t.Errorf("createYAMLVersionValidated: expected %q to contain %q", got, want)
+	}
+}
+
+// TestListParamVersionsByState tests the listParamVersionsByState function by creating three
+// versions and disabling one, and verifies the enabled and disabled listings.
+func TestListParamVersionsByState(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+
+	var versionNames []string
+	for i := 0; i < 3; i++ {
+		versionID, _ := setupTestParameterVersion(t, projectID, parameterID, fmt.Sprintf("payload-%d", i))
+		versionNames = append(versionNames, fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID))
+	}
+	testDisableParameterVersion(t, versionNames[0])
+
+	for _, tt := range []struct {
+		disabled bool
+		want     []string
+		notWant  []string
+	}{
+		{disabled: false, want: []string{versionNames[1], versionNames[2]}, notWant: []string{versionNames[0]}},
+		{disabled: true, want: []string{versionNames[0]}, notWant: []string{versionNames[1], versionNames[2]}},
+	} {
+		var buf bytes.Buffer
+		if err := listParamVersionsByState(&buf, projectID, parameterID, tt.disabled); err != nil {
+			t.Fatal(err)
+		}
+
+		got := buf.String()
+		for _, name := range tt.want {
+			if !strings.Contains(got, name) {
+				t.Errorf("listParamVersionsByState(disabled=%v): expected %q to contain %q", tt.disabled, got, name)
+			}
+		}
+		for _, name := range tt.notWant {
+			if strings.Contains(got, name) {
+				t.Errorf("listParamVersionsByState(disabled=%v): expected %q to not contain %q", tt.disabled, got, name)
+			}
+		}
+		if want := fmt.Sprintf("Found %d ", len(tt.want)); !strings.Contains(got, want) {
+			t.Errorf("listParamVersionsByState(disabled=%v): expected %q to contain %q", tt.disabled, got, want)
+		}
+	}
+}
+
+// TestDeleteDisabledVersions tests the deleteDisabledVersions function by creating three
+// versions and disabling two, and verifies a dry run keeps them all while a real run
+// removes exactly the disabled ones.
+func TestDeleteDisabledVersions(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+
+	var versionNames []string
+	for i := 0; i < 3; i++ {
+		versionID, _ := setupTestParameterVersion(t, projectID, parameterID, fmt.Sprintf("payload-%d", i))
+		versionNames = append(versionNames, fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID))
+	}
+	testDisableParameterVersion(t, versionNames[0])
+	testDisableParameterVersion(t, versionNames[1])
+
+	var buf bytes.Buffer
+	if err := deleteDisabledVersions(&buf, projectID, parameterID, true); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Would delete 2 disabled parameter versions"; !strings.Contains(got, want) {
+		t.Errorf("deleteDisabledVersions: expected %q to contain %q", got, want)
+	}
+	for _, name := range versionNames {
+		testGetParameterVersion(t, name)
+	}
+
+	buf.Reset()
+	if err := deleteDisabledVersions(&buf, projectID, parameterID, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Deleted 2 disabled parameter versions"; !strings.Contains(got, want) {
+		t.Errorf("deleteDisabledVersions: expected %q to contain %q", got, want)
+	}
+
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for i, name := range versionNames {
+		_, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{Name: name})
+		wantDeleted := i < 2
+		if deleted := grpcstatus.Code(err) == grpccodes.NotFound; deleted != wantDeleted {
+			t.Errorf("deleteDisabledVersions: expected %s deleted=%v, got error %v", name, wantDeleted, err)
+		}
+	}
+}
+
+// TestDeleteDisabledVersionsOnlyVersion tests the deleteDisabledVersions function on a
+// parameter whose only version is disabled and verifies the version is kept.
+func TestDeleteDisabledVersionsOnlyVersion(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	versionID, _ := setupTestParameterVersion(t, projectID, parameterID, "payload")
+	versionName := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+	testDisableParameterVersion(t, versionName)
+
+	var buf bytes.Buffer
+	if err := deleteDisabledVersions(&buf, projectID, parameterID, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Keeping parameter version "+versionName; !strings.Contains(got, want) {
+		t.Errorf("deleteDisabledVersions: expected %q to contain %q", got, want)
+	}
+	testGetParameterVersion(t, versionName)
+}
+
+// TestClassifyError tests the classifyError function with fabricated status errors and
+// verifies the hint returned for each code.
+func TestClassifyError(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		code grpccodes.Code
+		hint string
+	}{
+		{err: grpcstatus.Error(grpccodes.NotFound, "not found"), code: grpccodes.NotFound, hint: "check the resource ID"},
+		{err: grpcstatus.Error(grpccodes.PermissionDenied, "denied"), code: grpccodes.PermissionDenied, hint: "verify IAM roles and the service agent's KMS access"},
+		{err: grpcstatus.Error(grpccodes.FailedPrecondition, "has versions"), code: grpccodes.FailedPrecondition, hint: "delete child versions first"},
+		{err: grpcstatus.Error(grpccodes.AlreadyExists, "exists"), code: grpccodes.AlreadyExists, hint: "resource exists; use get or update"},
+		{err: fmt.Errorf("failed to get parameter: %w", grpcstatus.Error(grpccodes.NotFound, "not found")), code: grpccodes.NotFound, hint: "check the resource ID"},
+		{err: grpcstatus.Error(grpccodes.DataLoss, "lost"), code: grpccodes.DataLoss, hint: "see the error details for more information"},
+		{err: errors.New("plain error"), code: grpccodes.Unknown, hint: "see the error details for more information"},
+		{err: nil, code: grpccodes.OK, hint: ""},
+	} {
+		code, hint := classifyError(tt.err)
+		if code != tt.code || hint != tt.hint {
+			t.Errorf("classifyError(%v): expected (%v, %q), got (%v, %q)", tt.err, tt.code, tt.hint, code, hint)
+		}
+	}
+}
+
+// TestCreateVersionFromFile tests the createVersionFromFile function with a small temporary
+// file and verifies the stored payload matches the file contents.
+func TestCreateVersionFromFile(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	versionID := testName(t)
+	versionName := fmt.Sprintf("%s/versions/%s", parameterName, versionID)
+
+	contents := `{"username": "test-user"}`
+	filePath := filepath.Join(t.TempDir(), "payload.json")
+	if err := os.WriteFile(filePath, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := createVersionFromFile(&buf, projectID, parameterID, versionID, filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), fmt.Sprintf("Created parameter version %s from %d bytes", versionName, len(contents)); !strings.Contains(got, want) {
+		t.Errorf("createVersionFromFile: expected %q to contain %q", got, want)
+	}
+	if got := string(testGetParameterVersion(t, versionName).Payload.Data); got != contents {
+		t.Errorf("createVersionFromFile: expected payload %q, got %q", contents, got)
+	}
+}
+
+// TestCreateVersionFromFileTooLarge tests the createVersionFromFile function with a file
+// over the payload limit and verifies it is rejected before calling the API.
+func TestCreateVersionFromFileTooLarge(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "payload.bin")
+	if err := os.WriteFile(filePath, make([]byte, maxPayloadBytes+1), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	err := createVersionFromFile(io.Discard, "project", "parameter", "version", filePath)
+	if err == nil {
+		t.Fatal("createVersionFromFile: expected an error for a file over the payload limit")
+	}
+	if got, want := err.Error(), "byte limit"; !strings.Contains(got, want) {
+		t.Errorf("createVersionFromFile: expected %q to contain %q", got, want)
+	}
+}
+
+// TestCreateLargeVersion tests the createLargeVersion function with a payload at the service
+// limit and verifies the whole payload is stored.
+func TestCreateLargeVersion(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	versionID := testName(t)
+	versionName := fmt.Sprintf("%s/versions/%s", parameterName, versionID)
+
+	payload := bytes.Repeat([]byte("a"), maxPayloadBytes)
+
+	var buf bytes.Buffer
+	if err := createLargeVersion(&buf, projectID, parameterID, versionID, bytes.NewReader(payload), maxPayloadBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), fmt.Sprintf("Created parameter version %s from %d bytes", versionName, len(payload)); !strings.Contains(got, want) {
+		t.Errorf("createLargeVersion: expected %q to contain %q", got, want)
+	}
+	if got := len(testGetParameterVersion(t, versionName).Payload.Data); got != len(payload) {
+		t.Errorf("createLargeVersion: expected a %d byte payload, got %d", len(payload), got)
+	}
+}
+
+// TestRenderToFile tests the renderToFile function by rendering a known version to a
+// temporary file over an existing world-readable one and verifies the file contents and
+// permissions.
+func TestRenderToFile(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	payload := `{"username": "test-user", "host": "localhost"}`
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+
+	// Start from a world-readable file, so the test checks it is replaced with a private one.
+	outputPath := filepath.Join(t.TempDir(), "rendered.json")
+	if err := os.WriteFile(outputPath, []byte("stale"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderToFile(&buf, projectID, parameterID, parameterVersionID, outputPath); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), fmt.Sprintf("Wrote %d bytes of rendered payload to %s", len(payload), outputPath); !strings.Contains(got, want) {
+		t.Errorf("renderToFile: expected %q to contain %q", got, want)
+	}
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != payload {
+		t.Errorf("renderToFile: expected file contents %q, got %q", payload, got)
+	}
+
+	info, err := os.Stat(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := info.Mode().Perm(), os.FileMode(0o600); got != want {
+		t.Errorf("renderToFile: expected file mode %v, got %v", want, got)
+	}
+}
+
+// TestListParamsTable tests the listParamsTable function by creating parameters of
+// different formats and verifies the header row and a row per parameter with the
+// correct format column.
+func TestListParamsTable(t *testing.T) {
+	projectID := setupTestProject(t)
+
+	var parameters []*parametermanagerpb.Parameter
+	for _, format := range []parametermanagerpb.ParameterFormat{
+		parametermanagerpb.ParameterFormat_JSON,
+		parametermanagerpb.ParameterFormat_UNFORMATTED,
+		parametermanagerpb.ParameterFormat_YAML,
+	} {
+		_, parameterID, _ := setupTestParameter(t, format)
+		parameters = append(parameters, &parametermanagerpb.Parameter{
+			Name:   fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID),
+			Format: format,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := listParamsTable(&buf, projectID); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if got, want := strings.Fields(lines[0]), []string{"PARAMETER", "FORMAT", "KMS_KEY", "CREATE_TIME"}; strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("listParamsTable: expected header %q, got %q", want, got)
+	}
+
+	rows := make(map[string][]string)
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			t.Errorf("listParamsTable: expected 4 columns in row %q, got %d", line, len(fields))
+			continue
+		}
+		rows[fields[0]] = fields
+	}
+	for _, parameter := range parameters {
+		row, ok := rows[parameter.Name]
+		if !ok {
+			t.Errorf("listParamsTable: expected a row for %s", parameter.Name)
+			continue
+		}
+		if got, want := row[1], parameter.Format.String(); got != want {
+			t.Errorf("listParamsTable: expected format %s for %s, got %s", want, parameter.Name, got)
+		}
+	}
+}
+
+// TestListParamsJSON tests the listParamsJSON function by creating two parameters and
+// verifies the output unmarshals into a slice holding both with the expected fields.
+func TestListParamsJSON(t *testing.T) {
+	projectID, jsonParameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	jsonParameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, jsonParameterID)
+	_, yamlParameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_YAML)
+	yamlParameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, yamlParameterID)
+
+	var buf bytes.Buffer
+	if err := listParamsJSON(&buf, projectID); err != nil {
+		t.Fatal(err)
+	}
+
+	var summaries []paramSummary
+	if err := json.Unmarshal(buf.Bytes(), &summaries); err != nil {
+		t.Fatalf("listParamsJSON: output is not valid JSON: %v", err)
+	}
+
+	// The project may hold parameters from other tests, so only look at the two created here.
+	found := make(map[string]paramSummary)
+	for _, summary := range summaries {
+		if summary.Name == jsonParameterName || summary.Name == yamlParameterName {
+			found[summary.Name] = summary
+		}
+	}
+	if got, want := len(found), 2; got != want {
+		t.Fatalf("listParamsJSON: expected %d created parameters in the output, got %d", want, got)
+	}
+	for _, parameter := range []*parametermanagerpb.Parameter{
+		{Name: jsonParameterName, Format: parametermanagerpb.ParameterFormat_JSON},
+		{Name: yamlParameterName, Format: parametermanagerpb.ParameterFormat_YAML},
+	} {
+		if got, want := found[parameter.Name].Format, parameter.Format.String(); got != want {
+			t.Errorf("listParamsJSON: expected format %s for %s, got %s", want, parameter.Name, got)
+		}
+		if got := found[parameter.Name].KmsKey; got != "" {
+			t.Errorf("listParamsJSON: expected no kms_key for %s, got %s", parameter.Name, got)
+		}
+	}
+}
+
+// TestListParamsPaged tests the listParamsPaged function by creating five parameters and
+// listing them two at a time, and verifies the output shows multiple page boundaries.
+func TestListParamsPaged(t *testing.T) {
+	projectID := setupTestProject(t)
+
+	var parameterNames []string
+	for i := 0; i < 5; i++ {
+		_, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+		parameterNames = append(parameterNames, fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID))
+	}
+
+	var buf bytes.Buffer
+	if err := listParamsPaged(&buf, projectID, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Count(buf.String(), "--- Page "); got < 3 {
+		t.Errorf("listParamsPaged: expected at least 3 page markers, got %d in %q", got, buf.String())
+	}
+	for _, name := range parameterNames {
+		if got, want := buf.String(), fmt.Sprintf("Found parameter %s", name); !strings.Contains(got, want) {
+			t.Errorf("listParamsPaged: expected %q to contain %q", got, want)
+		}
+	}
+}
+
+// TestGetParamTimestamps tests the getParamTimestamps function by creating a parameter and
+// updating its labels, and verifies the update time is after the create time.
+func TestGetParamTimestamps(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Wait so the update time is distinguishable from the create time.
+	time.Sleep(time.Second)
+	if err := updateParamLabels(io.Discard, projectID, parameterID, map[string]string{"env": "test"}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := getParamTimestamps(&buf, projectID, parameterID); err != nil {
+		t.Fatal(err)
+	}
+
+	updated := testGetParameter(t, parameterName)
+	createTime := updated.GetCreateTime().AsTime()
+	updateTime := updated.GetUpdateTime().AsTime()
+	if !updateTime.After(createTime) {
+		t.Errorf("getParamTimestamps: expected update time %v to be after create time %v", updateTime, createTime)
+	}
+
+	for _, want := range []string{
+		fmt.Sprintf("Created: %s", createTime.UTC().Format(time.RFC3339)),
+		fmt.Sprintf("Updated: %s", updateTime.UTC().Format(time.RFC3339)),
+	} {
+		if got := buf.String(); !strings.Contains(got, want) {
+			t.Errorf("getParamTimestamps: expected %q to contain %q", got, want)
+		}
+	}
+}
+
+// TestDetectFormat tests the detectFormat function with JSON, YAML, and plain text
+// payloads and verifies the detected format for each.
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    parametermanagerpb.ParameterFormat
+	}{
+		{"json object", `{"username": "test-user"}`, parametermanagerpb.ParameterFormat_JSON},
+		{"json array", `[1, 2, 3]`, parametermanagerpb.ParameterFormat_JSON},
+		{"yaml mapping", "username: test-user\nhost: localhost\n", parametermanagerpb.ParameterFormat_YAML},
+		{"yaml sequence", "- a\n- b\n", parametermanagerpb.ParameterFormat_YAML},
+		{"plain text", "just some text", parametermanagerpb.ParameterFormat_UNFORMATTED},
+		{"malformed yaml", "key: [unclosed", parametermanagerpb.ParameterFormat_UNFORMATTED},
+		{"multiple yaml documents", "a: 1\n---\nb: 2\n", parametermanagerpb.ParameterFormat_UNFORMATTED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectFormat([]byte(tt.payload)); got != tt.want {
+				t.Errorf("detectFormat(%q): expected %s, got %s", tt.payload, tt.want, got)
+			}
+		})
+	}
+}
+
+// TestCreateParamAutoFormat tests the createParamAutoFormat function with a YAML payload
+// and verifies the parameter is created with the YAML format.
+func TestCreateParamAutoFormat(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	parameterID := testName(t)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", tc.ProjectID, parameterID)
+	defer testCleanupParameter(t, parameterName)
+
+	var buf bytes.Buffer
+	if err := createParamAutoFormat(&buf, tc.ProjectID, parameterID, []byte("username: test-user\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), fmt.Sprintf("Created parameter %s with detected format YAML", parameterName); !strings.Contains(got, want) {
+		t.Errorf("createParamAutoFormat: expected %q to contain %q", got, want)
+	}
+	if got := testGetParameter(t, parameterName).Format; got != parametermanagerpb.ParameterFormat_YAML {
+		t.Errorf("createParamAutoFormat: expected format YAML, got %s", got)
+	}
+}
+
+// TestConvertJSONVersionToYAML tests the convertJSONVersionToYAML function by converting a
+// JSON version and verifies the YAML version renders back to equivalent data.
+func TestConvertJSONVersionToYAML(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_YAML)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	payload := `{"username": "test-user", "port": 5432, "tls": {"enabled": true, "mode": "true"}, "hosts": ["a", "b"]}`
+	srcVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+	dstVersionID := testName(t)
+	dstVersionName := fmt.Sprintf("%s/versions/%s", parameterName, dstVersionID)
+
+	var buf bytes.Buffer
+	if err := convertJSONVersionToYAML(&buf, projectID, parameterID, srcVersionID, dstVersionID); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), fmt.Sprintf("to YAML parameter version %s", dstVersionName); !strings.Contains(got, want) {
+		t.Errorf("convertJSONVersionToYAML: expected %q to contain %q", got, want)
+	}
+
+	got, _, err := renderParamVersionYAML[interface{}](context.Background(), projectID, parameterID, dstVersionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want interface{}
+	if err := json.Unmarshal([]byte(payload), &want); err != nil {
+		t.Fatal(err)
+	}
+	// Round-trip the YAML data through JSON so both sides use the same number types.
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("convertJSONVersionToYAML: expected data %s, got %s", wantJSON, gotJSON)
+	}
+}
+
+// TestJSONToYAML tests the jsonToYAML helper and verifies it keeps the key order and
+// rejects invalid JSON.
+func TestJSONToYAML(t *testing.T) {
+	got, err := jsonToYAML([]byte(`{"z": 1, "a": {"mode": "true"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "z: 1\na:\n  mode: \"true\"\n"; string(got) != want {
+		t.Errorf("jsonToYAML: expected %q, got %q", want, got)
+	}
+
+	if _, err := jsonToYAML([]byte("not: json")); err == nil {
+		t.Error("jsonToYAML: expected an error for invalid JSON")
+	}
+}
+
+// TestListSecretReferences tests the listSecretReferences function with a version referencing
+// two secrets and a version with no references, and verifies the references returned.
+func TestListSecretReferences(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	secretA := testSecret(t, projectID)
+	secretB := testSecret(t, projectID)
+	defer testCleanupSecret(t, secretA.Name)
+	defer testCleanupSecret(t, secretB.Name)
+
+	refA := fmt.Sprintf("%s/versions/latest", secretA.Name)
+	refB := fmt.Sprintf("%s/versions/1", secretB.Name)
+	payload := fmt.Sprintf(`{"user": "__REF__(//secretmanager.googleapis.com/%s)", "password": "__REF__(//secretmanager.googleapis.com/%s)"}`, refA, refB)
+	withRefsID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+	withoutRefsID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"username": "test-user"}`)
+
+	var buf bytes.Buffer
+	refs, err := listSecretReferences(&buf, projectID, parameterID, withRefsID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(refs, ","), refA+","+refB; got != want {
+		t.Errorf("listSecretReferences: expected references %q, got %q", want, got)
+	}
+	if got, want := buf.String(), fmt.Sprintf("Found secret reference %s", refB); !strings.Contains(got, want) {
+		t.Errorf("listSecretReferences: expected %q to contain %q", got, want)
+	}
+
+	refs, err = listSecretReferences(io.Discard, projectID, parameterID, withoutRefsID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) != 0 {
+		t.Errorf("listSecretReferences: expected no references, got %q", refs)
+	}
+}
+
+// TestSecretRefRE tests the secret reference pattern with quoted, escaped-quoted,
+// unquoted, and regional references.
+func TestSecretRefRE(t *testing.T) {
+	payload := `a: __REF__(//secretmanager.googleapis.com/projects/p/secrets/s1/versions/1)
+b: __REF__("//secretmanager.googleapis.com/projects/p/secrets/s2/versions/latest")
+c: __REF__(//secretmanager.googleapis.com/projects/p/locations/us-central1/secrets/s3/versions/2)
+d: //secretmanager.googleapis.com/projects/p/secrets/not-a-ref/versions/1
+e: {"db_password": "__REF__(\"//secretmanager.googleapis.com/projects/p/secrets/s4/versions/latest\")"}`
+
+	var got []string
+	for _, match := range secretRefRE.FindAllStringSubmatch(payload, -1) {
+		got = append(got, match[1])
+	}
+	want := []string{
+		"projects/p/secrets/s1/versions/1",
+		"projects/p/secrets/s2/versions/latest",
+		"projects/p/locations/us-central1/secrets/s3/versions/2",
+		"projects/p/secrets/s4/versions/latest",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("secretRefRE: expected %q, got %q", want, got)
+	}
+}
+
+// TestMergeParams tests the mergeParams function by merging a base parameter with an
+// override parameter and verifies the override wins for shared keys.
+func TestMergeParams(t *testing.T) {
+	projectID, baseID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	baseVersionID, _ := setupTestParameterVersion(t, projectID, baseID, `{"a": 1, "b": 1}`)
+	_, overrideID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	overrideVersionID, _ := setupTestParameterVersion(t, projectID, overrideID, `{"b": 2, "c": 3}`)
+
+	got, err := mergeParams(context.Background(), projectID, []ParamRef{
+		{ParameterID: baseID, VersionID: baseVersionID},
+		{ParameterID: overrideID, VersionID: overrideVersionID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":1,"b":2,"c":3}`; string(gotJSON) != want {
+		t.Errorf("mergeParams: expected %s, got %s", want, gotJSON)
+	}
+}
+
+// TestDeepMerge tests the deepMerge helper and verifies nested objects are merged while
+// other values are replaced.
+func TestDeepMerge(t *testing.T) {
+	dst := map[string]interface{}{
+		"db":    map[string]interface{}{"host": "localhost", "port": 5432},
+		"hosts": []interface{}{"a", "b"},
+		"name":  map[string]interface{}{"first": "x"},
+	}
+	deepMerge(dst, map[string]interface{}{
+		"db":    map[string]interface{}{"host": "db.example.com"},
+		"hosts": []interface{}{"c"},
+		"name":  "replaced",
+	})
+
+	got, err := json.Marshal(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"db":{"host":"db.example.com","port":5432},"hosts":["c"],"name":"replaced"}`; string(got) != want {
+		t.Errorf("deepMerge: expected %s, got %s", want, got)
+	}
+}
+
+// TestWatchLatest tests the WatchLatest function by starting a watcher on a parameter with
+// one version, creating a second version, and verifies the new rendered payload arrives
+// and the channels are closed once the context is cancelled.
+func TestWatchLatest(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	first := `{"version": 1}`
+	setupTestParameterVersion(t, projectID, parameterID, first)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	values, errs := WatchLatest(ctx, projectID, parameterID, time.Second)
+
+	receive := func(want string) {
+		t.Helper()
+		timeout := time.After(30 * time.Second)
+		for {
+			select {
+			case got := <-values:
+				if string(got) == want {
+					return
+				}
+				t.Logf("WatchLatest: skipping payload %q while waiting for %q", got, want)
+			case err := <-errs:
+				t.Logf("WatchLatest: poll error: %v", err)
+			case <-timeout:
+				t.Fatalf("WatchLatest: timed out waiting for payload %q", want)
+			}
+		}
+	}
+
+	receive(first)
+
+	second := `{"version": 2}`
+	setupTestParameterVersion(t, projectID, parameterID, second)
+
+	receive(second)
+
+	cancel()
+	for range values {
+	}
+	for range errs {
+	}
+}
+
+// TestWatchLatestInvalidInterval tests that WatchLatest reports an interval that is not
+// positive on the error channel and closes both channels, instead of panicking.
+func TestWatchLatestInvalidInterval(t *testing.T) {
+	values, errs := WatchLatest(context.Background(), "fake-project", "parameter-0", 0)
+	if err := <-errs; err == nil || !strings.Contains(err.Error(), "invalid polling interval") {
+		t.Errorf("WatchLatest: expected an invalid interval error, got %v", err)
+	}
+	if _, ok := <-values; ok {
+		t.Error("WatchLatest: expected the values channel to be closed")
+	}
+	if _, ok := <-errs; ok {
+		t.Error("WatchLatest: expected the error channel to be closed")
+	}
+}
+
+// TestRenderTemplate tests the renderTemplate function by executing a template against a
+// JSON parameter and verifies the substituted output and the error for a missing key.
+func TestRenderTemplate(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"host": "db.example.com", "port": 5432}`)
+
+	params := map[string]ParamRef{
+		"db": {ParameterID: parameterID, VersionID: parameterVersionID},
+	}
+
+	var buf bytes.Buffer
+	if err := renderTemplate(&buf, projectID, "host={{.db.host}}", params); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "host=db.example.com"; got != want {
+		t.Errorf("renderTemplate: expected %q, got %q", want, got)
+	}
+
+	buf.Reset()
+	err := renderTemplate(&buf, projectID, "user={{.db.user}}", params)
+	if err == nil {
+		t.Fatal("renderTemplate: expected an error for a missing key")
+	}
+	if got, want := err.Error(), `map has no entry for key "user"`; !strings.Contains(got, want) {
+		t.Errorf("renderTemplate: expected %q to contain %q", got, want)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("renderTemplate: expected no output on error, got %q", buf.String())
+	}
+}
+
+// TestRenderWithEnvSubst tests the renderWithEnvSubst function with a payload holding a
+// placeholder, and verifies it is replaced when the key is supplied and rejected when not.
+func TestRenderWithEnvSubst(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"host": "${DB_HOST}"}`)
+
+	var buf bytes.Buffer
+	env := map[string]string{"DB_HOST": "db.example.com"}
+	if err := renderWithEnvSubst(&buf, projectID, parameterID, parameterVersionID, env, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), `{"host": "db.example.com"}`; !strings.Contains(got, want) {
+		t.Errorf("renderWithEnvSubst: expected %q to contain %q", got, want)
+	}
+
+	err := renderWithEnvSubst(io.Discard, projectID, parameterID, parameterVersionID, nil, false)
+	if err == nil {
+		t.Fatal("renderWithEnvSubst: expected an error for a missing variable")
+	}
+	if got, want := err.Error(), "undefined variables: DB_HOST"; !strings.Contains(got, want) {
+		t.Errorf("renderWithEnvSubst: expected %q to contain %q", got, want)
+	}
+}
+
+// TestSubstituteEnv tests the substituteEnv function with present and missing keys.
+func TestSubstituteEnv(t *testing.T) {
+	env := map[string]string{"A": "1", "B_2": "two"}
+
+	got, err := substituteEnv([]byte("${A}-${B_2}-$A-${A}"), env, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "1-two-$A-1"; string(got) != want {
+		t.Errorf("substituteEnv: expected %q, got %q", want, got)
+	}
+
+	if _, err := substituteEnv([]byte("${C} ${A} ${B}"), env, false); err == nil || !strings.Contains(err.Error(), "B, C") {
+		t.Errorf("substituteEnv: expected an error naming B and C, got %v", err)
+	}
+
+	got, err = substituteEnv([]byte("${C} ${A}"), env, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "${C} 1"; string(got) != want {
+		t.Errorf("substituteEnv: expected %q with allowMissing, got %q", want, got)
+	}
+}
+
+// TestAuditRenderAccess tests the auditRenderAccess function with a version referencing an
+// existing secret and, through an escaped-quote reference, a secret that does not exist,
+// and verifies the report flags each reference correctly.
+func TestAuditRenderAccess(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	_, secretID, _ := setupTestSecret(t)
+
+	accessibleRef := fmt.Sprintf("projects/%s/secrets/%s/versions/latest", projectID, secretID)
+	inaccessibleRef := fmt.Sprintf("projects/%s/secrets/%s/versions/latest", projectID, testResourceID(t))
+	payload := fmt.Sprintf(`{"a": "__REF__(//secretmanager.googleapis.com/%s)", "b": "__REF__(\"//secretmanager.googleapis.com/%s\")"}`, accessibleRef, inaccessibleRef)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+
+	var buf bytes.Buffer
+	if err := auditRenderAccess(&buf, projectID, parameterID, parameterVersionID); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		fmt.Sprintf("Accessible: %s", accessibleRef),
+		fmt.Sprintf("Not accessible: %s", inaccessibleRef),
+		"1 of 2 secret references are accessible",
+	} {
+		if got := buf.String(); !strings.Contains(got, want) {
+			t.Errorf("auditRenderAccess: expected %q to contain %q", got, want)
+		}
+	}
+}
+
+// TestBatchGetVersions tests the batchGetVersions function by fetching three versions and a
+// missing one, and verifies the three are returned along with a NotFound error.
+func TestBatchGetVersions(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+
+	var versionIDs []string
+	for i := 0; i < 3; i++ {
+		versionID, _ := setupTestParameterVersion(t, projectID, parameterID, fmt.Sprintf(`{"index": %d}`, i))
+		versionIDs = append(versionIDs, versionID)
+	}
+
+	ctx := context.Background()
+	versions, err := batchGetVersions(ctx, projectID, parameterID, versionIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(versions), 3; got != want {
+		t.Fatalf("batchGetVersions: expected %d versions, got %d", want, got)
+	}
+	for i, versionID := range versionIDs {
+		if got, want := string(versions[versionID].GetPayload().GetData()), fmt.Sprintf(`{"index": %d}`, i); got != want {
+			t.Errorf("batchGetVersions: expected payload %q for %s, got %q", want, versionID, got)
+		}
+	}
+
+	missingID := testName(t)
+	versions, err = batchGetVersions(ctx, projectID, parameterID, append(versionIDs, missingID))
+	if got, want := grpcstatus.Code(err), grpccodes.NotFound; got != want {
+		t.Errorf("batchGetVersions: expected code %v, got %v", want, got)
+	}
+	if got, want := err.Error(), fmt.Sprintf("failed to get parameter version %s", missingID); !strings.Contains(got, want) {
+		t.Errorf("batchGetVersions: expected %q to contain %q", got, want)
+	}
+	if got, want := len(versions), 3; got != want {
+		t.Errorf("batchGetVersions: expected %d partial results, got %d", want, got)
+	}
+}
+
+// TestRenderMany tests the renderMany function by rendering five versions concurrently and
+// verifies every rendered payload is returned, and that best-effort mode returns the
+// successful renders alongside the failure.
+func TestRenderMany(t *testing.T) {
+	projectID := setupTestProject(t)
+
+	var refs []ParamRef
+	want := make(map[string]string)
+	for i := 0; i < 5; i++ {
+		_, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+		payload := fmt.Sprintf(`{"index": %d}`, i)
+		versionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+
+		refs = append(refs, ParamRef{ParameterID: parameterID, VersionID: versionID})
+		want[parameterID+"/"+versionID] = payload
+	}
+
+	ctx := context.Background()
+	rendered, err := renderMany(ctx, projectID, refs, 3, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(rendered); got != len(want) {
+		t.Fatalf("renderMany: expected %d rendered versions, got %d", len(want), got)
+	}
+	for key, payload := range want {
+		if got := string(rendered[key]); got != payload {
+			t.Errorf("renderMany: expected %q for %s, got %q", payload, key, got)
+		}
+	}
+
+	missing := ParamRef{ParameterID: refs[0].ParameterID, VersionID: testName(t)}
+	rendered, err = renderMany(ctx, projectID, append(refs, missing), 3, true)
+	if got, want := grpcstatus.Code(err), grpccodes.NotFound; got != want {
+		t.Errorf("renderMany: expected code %v, got %v", want, got)
+	}
+	if got := len(rendered); got != len(want) {
+		t.Errorf("renderMany: expected %d partial results, got %d", len(want), got)
+	}
+}
+
+// TestDeleteParamVersionDryRun tests the deleteParamVersionDryRun function by previewing the
+// deletion of a version and verifying it still exists, then deleting it for real and
+// verifying it is gone.
+func TestDeleteParamVersionDryRun(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	payload := `{"username": "test-user", "host": "localhost"}`
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+	parameterVersionName := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, parameterVersionID)
+
+	var buf bytes.Buffer
+	if err := deleteParamVersionDryRun(&buf, projectID, parameterID, parameterVersionID, true); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), fmt.Sprintf("Would delete parameter version %s (disabled: false, payload: %d bytes)", parameterVersionName, len(payload)); !strings.Contains(got, want) {
+		t.Errorf("deleteParamVersionDryRun: expected %q to contain %q", got, want)
+	}
+
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{Name: parameterVersionName}); err != nil {
+		t.Fatalf("deleteParamVersionDryRun: expected the version to still exist after a dry run, got %v", err)
+	}
+
+	buf.Reset()
+	if err := deleteParamVersionDryRun(&buf, projectID, parameterID, parameterVersionID, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), fmt.Sprintf("Deleted parameter version: %s", parameterVersionName); !strings.Contains(got, want) {
+		t.Errorf("deleteParamVersionDryRun: expected %q to contain %q", got, want)
+	}
+
+	_, err = client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{Name: parameterVersionName})
+	if got, want := grpcstatus.Code(err), grpccodes.NotFound; got != want {
+		t.Errorf("deleteParamVersionDryRun: expected code %v after deleting, got %v", want, got)
+	}
+}
+
+// TestResolveLatest tests the resolveLatest function by creating two versions and disabling
+// the newer one, and verifies the older version ID is returned.
+func TestResolveLatest(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	v1ID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"version": 1}`)
+	v2ID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"version": 2}`)
+	v2Name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, v2ID)
+	testDisableParameterVersion(t, v2Name)
+
+	got, err := resolveLatest(context.Background(), projectID, parameterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != v1ID {
+		t.Errorf("resolveLatest: expected %q, got %q", v1ID, got)
+	}
+}
+
+// TestResolveLatestNoEnabledVersions tests the resolveLatest function on a parameter whose
+// only version is disabled and verifies an error is returned.
+func TestResolveLatestNoEnabledVersions(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	versionID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"version": 1}`)
+	versionName := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+	testDisableParameterVersion(t, versionName)
+
+	_, err := resolveLatest(context.Background(), projectID, parameterID)
+	if err == nil {
+		t.Fatal("resolveLatest: expected an error for a parameter without enabled versions")
+	}
+	if got, want := err.Error(), "has no enabled versions"; !strings.Contains(got, want) {
+		t.Errorf("resolveLatest: expected %q to contain %q", got, want)
+	}
+}
+
+// TestCreateVersionWithChecksum tests the createVersionWithChecksum function by creating a
+// version and verifies the stored payload checksum matches.
+func TestCreateVersionWithChecksum(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	versionID := testName(t)
+	versionName := fmt.Sprintf("%s/versions/%s", parameterName, versionID)
+
+	payload := []byte(`{"username": "test-user", "host": "localhost"}`)
+
+	var buf bytes.Buffer
+	if err := createVersionWithChecksum(&buf, projectID, parameterID, versionID, payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), fmt.Sprintf("Created parameter version %s with verified SHA-256 %x", versionName, sha256.Sum256(payload)); !strings.Contains(got, want) {
+		t.Errorf("createVersionWithChecksum: expected %q to contain %q", got, want)
+	}
+}
+
+// TestCreateVersionFromBase64 tests the createVersionFromBase64 function with an encoded
+// payload and verifies the stored version holds the decoded bytes.
+func TestCreateVersionFromBase64(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	versionID := testName(t)
+	versionName := fmt.Sprintf("%s/versions/%s", parameterName, versionID)
+
+	var buf bytes.Buffer
+	if err := createVersionFromBase64(&buf, projectID, parameterID, versionID, base64.StdEncoding.EncodeToString([]byte("hello"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), fmt.Sprintf("Created parameter version %s from 5 decoded bytes", versionName); !strings.Contains(got, want) {
+		t.Errorf("createVersionFromBase64: expected %q to contain %q", got, want)
+	}
+	if got := string(testGetParameterVersion(t, versionName).Payload.Data); got != "hello" {
+		t.Errorf("createVersionFromBase64: expected payload %q, got %q", "hello", got)
+	}
+}
+
+// TestCreateVersionFromBase64Malformed tests that createVersionFromBase64 rejects malformed
+// input before calling the API.
+func TestCreateVersionFromBase64Malformed(t *testing.T) {
+	err := createVersionFromBase64(io.Discard, "project", "parameter", "version", "not base64!")
+	if err == nil {
+		t.Fatal("createVersionFromBase64: expected an error for malformed base64")
+	}
+	if got, want := err.Error(), "payload is not valid base64"; !strings.Contains(got, want) {
+		t.Errorf("createVersionFromBase64: expected %q to contain %q", got, want)
+	}
+}
+
+// testRegions returns the two regions used by cross-region tests, or skips the test if
+// they are not configured.
+func testRegions(t *testing.T) (string, string) {
+	t.Helper()
+
+	a := os.Getenv("GOLANG_REGIONAL_SAMPLES_LOCATION")
+	b := os.Getenv("GOLANG_REGIONAL_SAMPLES_SECOND_LOCATION")
+	if a == "" || b == "" {
+		t.Skip("testRegions: missing GOLANG_REGIONAL_SAMPLES_LOCATION or GOLANG_REGIONAL_SAMPLES_SECOND_LOCATION")
+	}
+
+	return a, b
+}
+
+// testRegionalClient creates a Parameter Manager client for the endpoint of locationID and
+// closes it when the test finishes.
+func testRegionalClient(t *testing.T, locationID string) *parametermanager.Client {
+	t.Helper()
+
+	endpoint := fmt.Sprintf("parametermanager.%s.rep.googleapis.com:443", locationID)
+	client, err := parametermanager.NewClient(context.Background(), option.WithEndpoint(endpoint))
+	if err != nil {
+		t.Fatalf("testClient: failed to create client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+// testRegionalParameterVersion creates an UNFORMATTED parameter and a version holding payload
+// in the given region, and deletes both when the test finishes.
+func testRegionalParameterVersion(t *testing.T, projectID, locationID, parameterID, versionID, payload string) {
+	t.Helper()
+
+	ctx := context.Background()
+	client := testRegionalClient(t, locationID)
+
+	parameter, err := client.CreateParameter(ctx, &parametermanagerpb.CreateParameterRequest{
+		Parent:      fmt.Sprintf("projects/%s/locations/%s", projectID, locationID),
+		ParameterId: parameterID,
+		Parameter: &parametermanagerpb.Parameter{
+			Format: parametermanagerpb.ParameterFormat_UNFORMATTED,
+		},
+	})
+	if err != nil {
+		t.Fatalf("testRegionalParameterVersion: failed to create parameter: %v", err)
+	}
+	t.Cleanup(func() { testCleanupRegionalParameter(t, locationID, parameter.Name) })
+
+	_, err = client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parameter.Name,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: []byte(payload),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("testRegionalParameterVersion: failed to create parameter version: %v", err)
+	}
+}
+
+// testCleanupRegionalParameter deletes the versions of the named regional parameter and then
+// the parameter itself. A parameter that does not exist is not an error.
+func testCleanupRegionalParameter(t *testing.T, locationID, name string) {
+	t.Helper()
+
+	ctx := context.Background()
+	client := testRegionalClient(t, locationID)
+
+	it := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{Parent: name})
+	for {
+		version, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if grpcstatus.Code(err) == grpccodes.NotFound {
+			return
+		}
+		if err != nil {
+			t.Fatalf("testCleanupRegionalParameter: failed to list parameter versions: %v", err)
+		}
+		if err := client.DeleteParameterVersion(ctx, &parametermanagerpb.DeleteParameterVersionRequest{Name: version.Name}); err != nil && grpcstatus.Code(err) != grpccodes.NotFound {
+			t.Fatalf("testCleanupRegionalParameter: failed to delete parameter version: %v", err)
+		}
+	}
+
+	if err := client.DeleteParameter(ctx, &parametermanagerpb.DeleteParameterRequest{Name: name}); err != nil && grpcstatus.Code(err) != grpccodes.NotFound {
+		t.Fatalf("testCleanupRegionalParameter: failed to delete parameter: %v", err)
+	}
+}
+
+// testReadSnapshot reads a gzip-compressed projectSnapshot archive.
+// It fails the test if the archive cannot be read or parsed.
+func testReadSnapshot(t *testing.T, archivePath string) projectSnapshot {
+	t.Helper()
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("testReadSnapshot: archive is not gzip-compressed: %v", err)
+	}
+	defer zr.Close()
+
+	var snapshot projectSnapshot
+	if err := json.NewDecoder(zr).Decode(&snapshot); err != nil {
+		t.Fatalf("testReadSnapshot: failed to decode archive: %v", err)
+	}
+	return snapshot
+}
+
+// TestSnapshotProject tests the snapshotProject function by creating two parameters with
+// versions and verifies the archive holds their metadata and payloads.
+func TestSnapshotProject(t *testing.T) {
+	projectID := setupTestProject(t)
+
+	labeledParameter, labeledParameterID := testParameterWithLabels(t, projectID, map[string]string{"env": "test"})
+	t.Cleanup(func() { testDeleteParameterTree(t, labeledParameter.Name) })
+	labeledVersionID, _ := setupTestParameterVersion(t, projectID, labeledParameterID, `{"username": "test-user"}`)
+	_, yamlParameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_YAML)
+	yamlVersionID, _ := setupTestParameterVersion(t, projectID, yamlParameterID, "username: test-user\n")
+
+	archivePath := filepath.Join(t.TempDir(), "snapshot.json.gz")
+
+	var buf bytes.Buffer
+	if err := snapshotProject(&buf, projectID, archivePath); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), fmt.Sprintf("parameter versions to %s", archivePath); !strings.Contains(got, want) {
+		t.Errorf("snapshotProject: expected %q to contain %q", got, want)
+	}
+
+	snapshot := testReadSnapshot(t, archivePath)
+	for _, tt := range []struct {
+		format    parametermanagerpb.ParameterFormat
+		id        string
+		versionID string
+		payload   string
+	}{
+		{labeledParameter.Format, labeledParameterID, labeledVersionID, `{"username": "test-user"}`},
+		{parametermanagerpb.ParameterFormat_YAML, yamlParameterID, yamlVersionID, "username: test-user\n"},
+	} {
+		entry, ok := snapshot.Parameters[tt.id]
+		if !ok {
+			t.Errorf("snapshotProject: expected %s in the archive", tt.id)
+			continue
+		}
+		if got, want := entry.Format, tt.format.String(); got != want {
+			t.Errorf("snapshotProject: expected format %s for %s, got %s", want, tt.id, got)
+		}
+		if got, want := string(entry.Versions[tt.versionID].Payload), tt.payload; got != want {
+			t.Errorf("snapshotProject: expected payload %q for %s, got %q", want, tt.id, got)
+		}
+		if got := entry.Versions[tt.versionID].CreateTime; got.IsZero() {
+			t.Errorf("snapshotProject: expected a creation time for %s/%s", tt.id, tt.versionID)
+		}
+	}
+	if got := snapshot.Parameters[labeledParameterID].Labels["env"]; got != "test" {
+		t.Errorf("snapshotProject: expected label env=test for %s, got %q", labeledParameterID, got)
+	}
+}
+
+// testWriteSnapshot writes snapshot as a gzip-compressed archive.
+// It fails the test if the archive cannot be written.
+func testWriteSnapshot(t *testing.T, archivePath string, snapshot projectSnapshot) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(archivePath, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestRestoreProject tests the restoreProject function by snapshotting a project, restoring
+// a parameter from the archive under a fresh ID, and verifies the restored payloads match.
+// It then restores again without overwrite and verifies the parameter is skipped.
+func TestRestoreProject(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	enabledID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"version": 1}`)
+	disabledID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"version": 2}`)
+	disabledName := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, disabledID)
+	testDisableParameterVersion(t, disabledName)
+
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "snapshot.json.gz")
+	if err := snapshotProject(io.Discard, projectID, archivePath); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restore only the parameter created above, under a fresh ID.
+	entry, ok := testReadSnapshot(t, archivePath).Parameters[parameterID]
+	if !ok {
+		t.Fatalf("restoreProject: expected %s in the archive", parameterID)
+	}
+	restoredID := testName(t)
+	restoredName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, restoredID)
+	restorePath := filepath.Join(dir, "restore.json.gz")
+	testWriteSnapshot(t, restorePath, projectSnapshot{Parameters: map[string]parameterSnapshot{restoredID: entry}})
+	t.Cleanup(func() { testDeleteParameterTree(t, restoredName) })
+
+	var buf bytes.Buffer
+	if err := restoreProject(&buf, projectID, restorePath, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Restored 1 parameters and 2 parameter versions"; !strings.Contains(got, want) {
+		t.Errorf("restoreProject: expected %q to contain %q", got, want)
+	}
+
+	if got := testGetParameter(t, restoredName).Format; got != parametermanagerpb.ParameterFormat_JSON {
+		t.Errorf("restoreProject: expected format JSON, got %s", got)
+	}
+	for _, tt := range []struct {
+		versionID string
+		payload   string
+		disabled  bool
+	}{
+		{enabledID, `{"version": 1}`, false},
+		{disabledID, `{"version": 2}`, true},
+	} {
+		version := testGetParameterVersion(t, fmt.Sprintf("%s/versions/%s", restoredName, tt.versionID))
+		if got := string(version.GetPayload().GetData()); got != tt.payload {
+			t.Errorf("restoreProject: expected payload %q for %s, got %q", tt.payload, tt.versionID, got)
+		}
+		if version.Disabled != tt.disabled {
+			t.Errorf("restoreProject: expected disabled=%v for %s, got %v", tt.disabled, tt.versionID, version.Disabled)
+		}
+	}
+	// The versions are recreated in their original order, whatever their IDs.
+	restoredEnabled := testGetParameterVersion(t, fmt.Sprintf("%s/versions/%s", restoredName, enabledID))
+	restoredDisabled := testGetParameterVersion(t, fmt.Sprintf("%s/versions/%s", restoredName, disabledID))
+	if !restoredEnabled.CreateTime.AsTime().Before(restoredDisabled.CreateTime.AsTime()) {
+		t.Errorf("restoreProject: expected %s to be recreated before %s", enabledID, disabledID)
+	}
+
+	buf.Reset()
+	if err := restoreProject(&buf, projectID, restorePath, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), fmt.Sprintf("Parameter %s already exists, skipping", restoredID); !strings.Contains(got, want) {
+		t.Errorf("restoreProject: expected %q to contain %q", got, want)
+	}
+}
+
+// TestCompareAcrossRegions tests the compareAcrossRegions function with matching versions in
+// two regions, and with a version that exists in only one of them.
+func TestCompareAcrossRegions(t *testing.T) {
+	tc := testutil.SystemTest(t)
+	regionA, regionB := testRegions(t)
+
+	parameterID := testName(t)
+	versionID := testName(t)
+	payload := "log_level=debug"
+	testRegionalParameterVersion(t, tc.ProjectID, regionA, parameterID, versionID, payload)
+	testRegionalParameterVersion(t, tc.ProjectID, regionB, parameterID, versionID, payload)
+
+	var buf bytes.Buffer
+	if err := compareAcrossRegions(&buf, tc.ProjectID, parameterID, versionID, regionA, regionB); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "identical"; !strings.Contains(got, want) {
+		t.Errorf("compareAcrossRegions: expected %q to contain %q", got, want)
+	}
+
+	onlyInA := testName(t)
+	testRegionalParameterVersion(t, tc.ProjectID, regionA, onlyInA, versionID, payload)
+
+	buf.Reset()
+	if err := compareAcrossRegions(&buf, tc.ProjectID, onlyInA, versionID, regionA, regionB); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), fmt.Sprintf("not found in %s", regionB); !strings.Contains(got, want) {
+		t.Errorf("compareAcrossRegions: expected %q to contain %q", got, want)
+	}
+}
+
+// TestReplicateToRegions tests the replicateToRegions function by replicating a global JSON
+// version to two regions, and verifies each regional version holds the same payload.
+func TestReplicateToRegions(t *testing.T) {
+	regionA, regionB := testRegions(t)
+
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	payload := `{"log_level": "debug"}`
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+
+	regions := []string{regionA, regionB}
+	for _, region := range regions {
+		name := fmt.Sprintf("projects/%s/locations/%s/parameters/%s", projectID, region, parameterID)
+		defer testCleanupRegionalParameter(t, region, name)
+	}
+
+	var buf bytes.Buffer
+	if err := replicateToRegions(&buf, projectID, parameterID, parameterVersionID, regions); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Replicated to 2 of 2 regions"; !strings.Contains(got, want) {
+		t.Errorf("replicateToRegions: expected %q to contain %q", got, want)
+	}
+
+	for _, region := range regions {
+		name := fmt.Sprintf("projects/%s/locations/%s/parameters/%s/versions/%s", projectID, region, parameterID, parameterVersionID)
+		version, err := testRegionalClient(t, region).GetParameterVersion(context.Background(), &parametermanagerpb.GetParameterVersionRequest{
+			Name: name,
+		})
+		if err != nil {
+			t.Fatalf("replicateToRegions: failed to get %s: %v", name, err)
+		}
+		if got := string(version.Payload.Data); got != payload {
+			t.Errorf("replicateToRegions: expected %s payload %q, got %q", region, payload, got)
+		}
+	}
+}
+
+// TestListParamsSorted tests the listParamsSorted function by creating three parameters one
+// second apart, and verifies they are printed in creation order, or in reverse with
+// newestFirst. It runs against an in-memory server when no project is configured.
+func TestListParamsSorted(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	var names []string
+	for i := 0; i < 3; i++ {
+		if i > 0 {
+			time.Sleep(time.Second)
+		}
+		parameterID := testName(t)
+		if err := createParamWithClient(ctx, client, io.Discard, projectID, parameterID); err != nil {
+			t.Fatal(err)
+		}
+		name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+		t.Cleanup(func() { testDeleteParameterTreeWithClient(t, client, name) })
+		names = append(names, name)
+	}
+
+	for _, newestFirst := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := listParamsSortedWithClient(ctx, client, &buf, projectID, newestFirst); err != nil {
+			t.Fatal(err)
+		}
+
+		// Other parameters in the project may be listed too, so only compare positions.
+		var positions []int
+		for _, name := range names {
+			i := strings.Index(buf.String(), "Found parameter "+name+" ")
+			if i < 0 {
+				t.Fatalf("listParamsSorted: expected %q to contain %s", buf.String(), name)
+			}
+			positions = append(positions, i)
+		}
+		ascending := positions[0] < positions[1] && positions[1] < positions[2]
+		descending := positions[0] > positions[1] && positions[1] > positions[2]
+		if newestFirst && !descending || !newestFirst && !ascending {
+			t.Errorf("listParamsSorted(newestFirst=%v): parameters printed in the wrong order: %q", newestFirst, buf.String())
+		}
+	}
+}
+
+// TestMergeVersions tests the mergeVersions function by merging {"a": 1} and {"b": 2}, and
+// verifies the new version holds both keys with a large integer intact, and that an
+// object/scalar conflict is rejected with its path, always naming the first conflicting
+// key in sorted order. It runs against an in-memory server when no project is configured.
+func TestMergeVersions(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	// The parameter cleanup also deletes the merged version the sample creates.
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	versions := map[string]string{
+		"base":      `{"a": 1, "id": 9007199254740993, "nested": {"x": 1}}`,
+		"overlay":   `{"b": 2}`,
+		"conflict":  `{"nested": {"x": {"y": 1}}}`,
+		"conflicts": `{"nested": {"x": {"y": 1}}, "a": {"z": 1}}`,
+	}
+	for versionID, payload := range versions {
+		setupTestParameterVersionWithClient(t, client, projectID, parameterID, versionID, payload)
+	}
+	mergedName := fmt.Sprintf("%s/versions/merged", parameterName)
+
+	var buf bytes.Buffer
+	if err := mergeVersionsWithClient(ctx, client, &buf, projectID, parameterID, "base", "overlay", "merged"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Created merged parameter version: "+mergedName; !strings.Contains(got, want) {
+		t.Errorf("mergeVersions: expected %q to contain %q", got, want)
+	}
+
+	merged, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{Name: mergedName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(merged.Payload.Data), `{"a":1,"b":2,"id":9007199254740993,"nested":{"x":1}}`; got != want {
+		t.Errorf("mergeVersions: expected payload %s, got %s", want, got)
+	}
+
+	err = mergeVersionsWithClient(ctx, client, io.Discard, projectID, parameterID, "base", "conflict", "conflicted")
+	if err == nil {
+		t.Fatal("mergeVersions: expected an error for conflicting types")
+	}
+	if got, want := err.Error(), "cannot merge nested.x"; !strings.Contains(got, want) {
+		t.Errorf("mergeVersions: expected %q to contain %q", got, want)
+	}
+
+	for i := 0; i < 10; i++ {
+		err = mergeVersionsWithClient(ctx, client, io.Discard, projectID, parameterID, "base", "conflicts", "conflicted")
+		if err == nil {
+			t.Fatal("mergeVersions: expected an error for conflicting types")
+		}
+		if got, want := err.Error(), "cannot merge a:"; !strings.Contains(got, want) {
+			t.Fatalf("mergeVersions: expected %q to contain %q", got, want)
+		}
+	}
+}
+
+// TestNotifyOnVersionCreate tests the notifyOnVersionCreate function with a temporary topic
+// and subscription, and verifies the received message carries the parameter and version
+// attributes.
+func TestNotifyOnVersionCreate(t *testing.T) {
+	ctx := context.Background()
+
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	versionID := testName(t)
+
+	client, err := pubsub.NewClient(ctx, projectID)
+	if err != nil {
+		t.Fatalf("testClient: failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	topicID := "test-" + testName(t)
+	topic, err := client.CreateTopic(ctx, topicID)
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	defer topic.Delete(ctx)
+	subscription, err := client.CreateSubscription(ctx, topicID, pubsub.SubscriptionConfig{Topic: topic})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+	defer subscription.Delete(ctx)
+
+	payload := []byte(`{"username": "test-user"}`)
+	var buf bytes.Buffer
+	if err := notifyOnVersionCreate(&buf, projectID, parameterID, versionID, topicID, payload); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Published notification to topic "+topicID; !strings.Contains(got, want) {
+		t.Errorf("notifyOnVersionCreate: expected %q to contain %q", got, want)
+	}
+
+	// Wait for the single message, or give up after a minute.
+	receiveCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	var attributes map[string]string
+	err = subscription.Receive(receiveCtx, func(ctx context.Context, msg *pubsub.Message) {
+		msg.Ack()
+		attributes = msg.Attributes
+		cancel()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attributes == nil {
+		t.Fatal("notifyOnVersionCreate: no message received")
+	}
+
+	for key, want := range map[string]string{
+		"parameter":    parameterName,
+		"version":      versionID,
+		"payload_size": strconv.Itoa(len(payload)),
+	} {
+		if got := attributes[key]; got != want {
+			t.Errorf("notifyOnVersionCreate: expected attribute %s %q, got %q", key, want, got)
+		}
+	}
+}
+
+// TestListVersionsSince tests the listVersionsSince function by creating two versions,
+// capturing a timestamp and creating a third, and verifies only the third is printed.
+// It runs against an in-memory server when no project is configured.
+func TestListVersionsSince(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	createVersion := func(versionID string) string {
+		name, _ := setupTestParameterVersionWithClient(t, client, projectID, parameterID, versionID, versionID)
+		return name
+	}
+	before := []string{createVersion("v1"), createVersion("v2")}
+
+	// Leave a margin on both sides of the timestamp for clock differences with the service.
+	time.Sleep(time.Second)
+	since := time.Now()
+	time.Sleep(time.Second)
+	after := createVersion("v3")
+
+	var buf bytes.Buffer
+	if err := listVersionsSinceWithClient(ctx, client, &buf, projectID, parameterID, since); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Found parameter version "+after+" "; !strings.Contains(got, want) {
+		t.Errorf("listVersionsSince: expected %q to contain %q", got, want)
+	}
+	for _, name := range before {
+		if got := buf.String(); strings.Contains(got, name+" ") {
+			t.Errorf("listVersionsSince: expected %q not to contain %s", got, name)
+		}
+	}
+	if got, want := buf.String(), "Found 1 parameter versions created since"; !strings.Contains(got, want) {
+		t.Errorf("listVersionsSince: expected %q to contain %q", got, want)
+	}
+}
+
+// TestReportEncryption tests the reportEncryption function by creating a parameter with a
+// KMS key and one without, and verifies each is classified correctly.
+func TestReportEncryption(t *testing.T) {
+	projectID := setupTestProject(t)
+
+	keyId := testName(t)
+	testCreateKeyRing(t, projectID, "go-test-key-ring")
+	testCreateKeyHSM(t, projectID, "go-test-key-ring", keyId)
+	kms_key := fmt.Sprintf("projects/%s/locations/global/keyRings/go-test-key-ring/cryptoKeys/%s", projectID, keyId)
+	defer testCleanupKeyVersions(t, fmt.Sprintf("%s/cryptoKeyVersions/1", kms_key))
+
+	cmekParameter, _ := testParameterWithKmsKey(t, projectID, kms_key)
+	defer testCleanupParameter(t, cmekParameter.Name)
+	_, defaultParameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	defaultParameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, defaultParameterID)
+
+	var buf bytes.Buffer
+	if err := reportEncryption(&buf, projectID); err != nil {
+		t.Fatal(err)
+	}
+
+	// tabwriter pads the name column, so match each row by its fields.
+	rows := make(map[string]string)
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			rows[fields[0]] = fields[1]
+		}
+	}
+	if got, want := rows[cmekParameter.Name], "CMEK:"+kms_key; got != want {
+		t.Errorf("reportEncryption: expected %s to be %q, got %q", cmekParameter.Name, want, got)
+	}
+	if got, want := rows[defaultParameterName], "Google-managed"; got != want {
+		t.Errorf("reportEncryption: expected %s to be %q, got %q", defaultParameterName, want, got)
+	}
+}
+
+// testConfigSchema is the JSON Schema used by the createVersionWithSchema tests.
+const testConfigSchema = `{
+	"type": "object",
+	"properties": {
+		"host": {"type": "string"},
+		"port": {"type": "integer", "minimum": 1}
+	},
+	"required": ["host", "port"]
+}`
+
+// TestCreateVersionWithSchema tests the createVersionWithSchema function with a payload that
+// conforms to the schema and one that does not, and verifies only the first is stored.
+func TestCreateVersionWithSchema(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	versionID := testName(t)
+	versionName := fmt.Sprintf("%s/versions/%s", parameterName, versionID)
+
+	payload := `{"host": "db.example.com", "port": 5432}`
+	var buf bytes.Buffer
+	if err := createVersionWithSchema(&buf, projectID, parameterID, versionID, []byte(payload), []byte(testConfigSchema)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Created parameter version: "+versionName; !strings.Contains(got, want) {
+		t.Errorf("createVersionWithSchema: expected %q to contain %q", got, want)
+	}
+	if got := string(testGetParameterVersion(t, versionName).Payload.Data); got != payload {
+		t.Errorf("createVersionWithSchema: expected payload %q, got %q", payload, got)
+	}
+
+	rejectedID := testName(t)
+	err := createVersionWithSchema(io.Discard, projectID, parameterID, rejectedID, []byte(`{"host": "db.example.com", "port": "5432"}`), []byte(testConfigSchema))
+	if err == nil {
+		t.Fatal("createVersionWithSchema: expected an error for a non-conforming payload")
+	}
+	if got, want := err.Error(), "/port: expected integer"; !strings.Contains(got, want) {
+		t.Errorf("createVersionWithSchema: expected %q to contain %q", got, want)
+	}
+}
+
+// TestValidateSchema tests the validateSchema function with conforming and non-conforming
+// payloads, and verifies every violation is listed with its location.
+func TestValidateSchema(t *testing.T) {
+	if err := validateSchema([]byte(`{"host": "h", "port": 1}`), []byte(testConfigSchema)); err != nil {
+		t.Errorf("validateSchema: expected a conforming payload to pass, got %v", err)
+	}
+
+	err := validateSchema([]byte(`{"port": 0}`), []byte(testConfigSchema))
+	if err == nil {
+		t.Fatal("validateSchema: expected an error for a non-conforming payload")
+	}
+	for _, want := range []string{"/: missing properties: 'host'", "/port: must be >= 1"} {
+		if got := err.Error(); !strings.Contains(got, want) {
+			t.Errorf("validateSchema: expected %q to contain %q", got, want)
+		}
+	}
+
+	err = validateSchema([]byte(`{"host": "h", "port": "1"}`), []byte(testConfigSchema))
+	if got, want := fmt.Sprint(err), "/port: expected integer"; !strings.Contains(got, want) {
+		t.Errorf("validateSchema: expected %q to contain %q", got, want)
+	}
+
+	if err := validateSchema([]byte(`{}`), []byte(`{"type": 1}`)); err == nil || !strings.Contains(err.Error(), "failed to compile schema") {
+		t.Errorf("validateSchema: expected a schema compilation error, got %v", err)
+	}
+}
+
+// TestVersionHistory tests the versionHistory function with two versions holding the same
+// payload and one holding another, and verifies the order and which checksums match.
+// It runs against an in-memory server when no project is configured.
+func TestVersionHistory(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	for _, v := range []struct{ id, payload string }{
+		{"v1", "same"},
+		{"v2", "different"},
+		{"v3", "same"},
+	} {
+		setupTestParameterVersionWithClient(t, client, projectID, parameterID, v.id, v.payload)
+		// Space the versions out so their creation times are distinct.
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	history, err := versionHistoryWithClient(ctx, client, projectID, parameterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("versionHistory: expected 3 versions, got %d", len(history))
+	}
+	for i, want := range []string{"v1", "v2", "v3"} {
+		if got := history[i].VersionID; got != want {
+			t.Errorf("versionHistory: expected version %d to be %s, got %s", i, want, got)
+		}
+	}
+	if history[0].SHA256 != history[2].SHA256 {
+		t.Errorf("versionHistory: expected v1 and v3 to share a checksum, got %x and %x", history[0].SHA256, history[2].SHA256)
+	}
+	if history[0].SHA256 == history[1].SHA256 {
+		t.Errorf("versionHistory: expected v1 and v2 checksums to differ, both are %x", history[0].SHA256)
+	}
+}
+
+// TestPromoteToFormat tests the promoteToFormat function by promoting a valid and an invalid
+// JSON payload from an UNFORMATTED parameter into a JSON parameter, and verifies only the
+// valid one is created. It runs against an in-memory server when no project is configured.
+func TestPromoteToFormat(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	createParameter := func(format parametermanagerpb.ParameterFormat, payloads map[string]string) string {
+		parameterID, _ := setupTestParameterWithClient(t, client, projectID, format)
+		for _, versionID := range []string{"valid", "invalid"} {
+			if payload, ok := payloads[versionID]; ok {
+				setupTestParameterVersionWithClient(t, client, projectID, parameterID, versionID, payload)
+			}
+		}
+		return parameterID
+	}
+
+	payload := `{"username": "test-user"}`
+	srcParameterID := createParameter(parametermanagerpb.ParameterFormat_UNFORMATTED, map[string]string{
+		"valid":   payload,
+		"invalid": "username=test-user",
+	})
+	dstParameterID := createParameter(parametermanagerpb.ParameterFormat_JSON, nil)
+
+	var buf bytes.Buffer
+	if err := promoteToFormatWithClient(ctx, client, &buf, projectID, srcParameterID, "valid", dstParameterID, "promoted"); err != nil {
+		t.Fatal(err)
+	}
+	dstName := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/promoted", projectID, dstParameterID)
+	if got, want := buf.String(), "to JSON parameter version "+dstName; !strings.Contains(got, want) {
+		t.Errorf("promoteToFormat: expected %q to contain %q", got, want)
+	}
+	version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{Name: dstName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(version.Payload.Data); got != payload {
+		t.Errorf("promoteToFormat: expected payload %q, got %q", payload, got)
+	}
+
+	err = promoteToFormatWithClient(ctx, client, io.Discard, projectID, srcParameterID, "invalid", dstParameterID, "rejected")
+	if err == nil {
+		t.Fatal("promoteToFormat: expected an error for a payload that is not valid JSON")
+	}
+	if got, want := err.Error(), "payload is not valid JSON"; !strings.Contains(got, want) {
+		t.Errorf("promoteToFormat: expected %q to contain %q", got, want)
+	}
+}
+
+// TestPruneOldVersions tests the pruneOldVersions function by creating five versions and
+// pruning to the two newest, and verifies exactly the three oldest are deleted. It then
+// disables the remaining versions but one old one, and verifies that version is kept.
+// It runs against an in-memory server when no project is configured.
+func TestPruneOldVersions(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+
+	var names []string
+	for i := 1; i <= 5; i++ {
+		name, _ := setupTestParameterVersionWithClient(t, client, projectID, parameterID, fmt.Sprintf("v%d", i), "payload")
+		names = append(names, name)
+		// Space the versions out so their creation times are distinct.
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	exists := func(name string) bool {
+		_, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{Name: name})
+		if err != nil && grpcstatus.Code(err) != grpccodes.NotFound {
+			t.Fatal(err)
+		}
+		return err == nil
+	}
+
+	var buf bytes.Buffer
+	if err := pruneOldVersionsWithClient(ctx, client, &buf, projectID, parameterID, 2, true); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Would prune 3 parameter versions"; !strings.Contains(got, want) {
+		t.Errorf("pruneOldVersions: expected %q to contain %q", got, want)
+	}
+	for _, name := range names {
+		if !exists(name) {
+			t.Errorf("pruneOldVersions: expected dry run to keep %s", name)
+		}
+	}
+
+	buf.Reset()
+	if err := pruneOldVersionsWithClient(ctx, client, &buf, projectID, parameterID, 2, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Pruned 3 parameter versions"; !strings.Contains(got, want) {
+		t.Errorf("pruneOldVersions: expected %q to contain %q", got, want)
+	}
+	for i, name := range names {
+		if got, want := exists(name), i >= 3; got != want {
+			t.Errorf("pruneOldVersions: expected %s to exist: %v, got %v", name, want, got)
+		}
+	}
+
+	// With v5 disabled, v4 is the only enabled version and must survive keep=0.
+	if _, err := client.UpdateParameterVersion(ctx, &parametermanagerpb.UpdateParameterVersionRequest{
+		ParameterVersion: &parametermanagerpb.ParameterVersion{Name: names[4], Disabled: true},
+		UpdateMask:       &field_mask.FieldMask{Paths: []string{"disabled"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := pruneOldVersionsWithClient(ctx, client, &buf, projectID, parameterID, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), fmt.Sprintf("Keeping parameter version %s because it is the only enabled version", names[3]); !strings.Contains(got, want) {
+		t.Errorf("pruneOldVersions: expected %q to contain %q", got, want)
+	}
+	if !exists(names[3]) || exists(names[4]) {
+		t.Errorf("pruneOldVersions: expected only %s to remain", names[3])
+	}
+}
+
+// TestEnsureVersion tests the ensureVersion function by calling it twice with the same
+// payload and once with another, and verifies it creates, then reuses, then reports a
+// conflict. It runs against an in-memory server when no project is configured.
+func TestEnsureVersion(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	versionID := testName(t)
+
+	payload := []byte("log_level=debug")
+	first, created, err := ensureVersionWithClient(ctx, client, projectID, parameterID, versionID, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created {
+		t.Error("ensureVersion: expected the first call to create the version")
+	}
+
+	second, created, err := ensureVersionWithClient(ctx, client, projectID, parameterID, versionID, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created {
+		t.Error("ensureVersion: expected the second call to find the existing version")
+	}
+	if second.Name != first.Name {
+		t.Errorf("ensureVersion: expected version %s, got %s", first.Name, second.Name)
+	}
+
+	_, _, err = ensureVersionWithClient(ctx, client, projectID, parameterID, versionID, []byte("log_level=info"))
+	if !errors.Is(err, errVersionConflict) {
+		t.Errorf("ensureVersion: expected errVersionConflict for a different payload, got %v", err)
+	}
+}
+
+// TestRenderAsServiceAccount tests the renderAsServiceAccount function by rendering a
+// parameter version while impersonating a service account and verifies the output names
+// that identity. The caller must be able to impersonate the account, and the account
+// must be able to render parameters in the project.
+func TestRenderAsServiceAccount(t *testing.T) {
+	targetSA := os.Getenv("GOLANG_SAMPLES_IMPERSONATED_SERVICE_ACCOUNT")
+	if targetSA == "" {
+		t.Skip("GOLANG_SAMPLES_IMPERSONATED_SERVICE_ACCOUNT not set")
+	}
+
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	versionID, _ := setupTestParameterVersion(t, projectID, parameterID, `{"log_level": "debug"}`)
+
+	var buf bytes.Buffer
+	if err := renderAsServiceAccount(&buf, projectID, parameterID, versionID, targetSA); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), "as "+targetSA; !strings.Contains(got, want) {
+		t.Errorf("renderAsServiceAccount: expected %q to contain %q", got, want)
+	}
+	if got, want := buf.String(), `{"log_level": "debug"}`; !strings.Contains(got, want) {
+		t.Errorf("renderAsServiceAccount: expected %q to contain %q", got, want)
+	}
+}
+
+// TestDescribeParam tests the describeParam function on a labeled parameter with two
+// versions and verifies the returned metadata. It runs against an in-memory server when
+// no project is configured.
+func TestDescribeParam(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID := testName(t)
+	labels := map[string]string{"env": "test", "team": "platform"}
+	param, err := client.CreateParameter(ctx, &parametermanagerpb.CreateParameterRequest{
+		Parent:      fmt.Sprintf("projects/%s/locations/global", projectID),
+		ParameterId: parameterID,
+		Parameter: &parametermanagerpb.Parameter{
+			Format: parametermanagerpb.ParameterFormat_JSON,
+			Labels: labels,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	onceCleanup(t, func() { testDeleteParameterTreeWithClient(t, client, param.Name) })
+
+	for _, id := range []string{"v1", "v2"} {
+		setupTestParameterVersionWithClient(t, client, projectID, parameterID, id, `{"version": "`+id+`"}`)
+	}
+
+	desc, err := describeParamWithClient(ctx, client, projectID, parameterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if desc.Name != param.Name {
+		t.Errorf("describeParam: expected name %s, got %s", param.Name, desc.Name)
+	}
+	if got, want := desc.Format, "JSON"; got != want {
+		t.Errorf("describeParam: expected format %s, got %s", want, got)
+	}
+	if desc.KmsKey != "" {
+		t.Errorf("describeParam: expected no KMS key, got %s", desc.KmsKey)
+	}
+	if !maps.Equal(desc.Labels, labels) {
+		t.Errorf("describeParam: expected labels %v, got %v", labels, desc.Labels)
+	}
+	if desc.CreateTime.IsZero() || desc.UpdateTime.IsZero() {
+		t.Errorf("describeParam: expected create and update times, got %s and %s", desc.CreateTime, desc.UpdateTime)
+	}
+	if desc.VersionCount != 2 {
+		t.Errorf("describeParam: expected 2 versions, got %d", desc.VersionCount)
+	}
+}
+
+// TestAssertRenderEquals tests the assertRenderEquals function against a known version and
+// verifies a match returns nil and a mismatch returns a diff with the keys but no values.
+// It runs against an in-memory server when no project is configured.
+func TestAssertRenderEquals(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+
+	payload := []byte("host=db.example.com\nport=5432\n")
+	setupTestParameterVersionWithClient(t, client, projectID, parameterID, "v1", string(payload))
+
+	if err := assertRenderEqualsWithClient(ctx, client, projectID, parameterID, "v1", payload); err != nil {
+		t.Errorf("assertRenderEquals: expected a match, got %v", err)
+	}
+
+	err := assertRenderEqualsWithClient(ctx, client, projectID, parameterID, "v1", []byte("host=db.example.com\nport=6543\n"))
+	if err == nil {
+		t.Fatal("assertRenderEquals: expected an error for a different payload")
+	}
+	for _, want := range []string{"@@ -1,3 +1,3 @@", " host=***", "-port=***", "+port=***"} {
+		if got := err.Error(); !strings.Contains(got, want) {
+			t.Errorf("assertRenderEquals: expected %q to contain %q", got, want)
+		}
+	}
+	for _, value := range []string{"db.example.com", "5432", "6543"} {
+		if got := err.Error(); strings.Contains(got, value) {
+			t.Errorf("assertRenderEquals: expected %q not to contain the value %q", got, value)
+		}
+	}
+}
+
+// TestRenderAsEnvFile tests the renderAsEnvFile function on a JSON object version with
+// string, number, and nested fields, and verifies the quoted lines and the skipped
+// nested object. It runs against an in-memory server when no project is configured.
+func TestRenderAsEnvFile(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+
+	setupTestParameterVersionWithClient(t, client, projectID, parameterID, "v1", `{"host": "db", "port": 5432, "password": "a\"$b", "tls": {"enabled": true}}`)
+
+	var buf bytes.Buffer
+	if err := renderAsEnvFileWithClient(ctx, client, &buf, projectID, parameterID, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{
+		`HOST="db"`,
+		`PORT="5432"`,
+		`PASSWORD="a\"\$b"`,
+		`# Skipping "tls": nested objects are not supported`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderAsEnvFile: expected %q to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "TLS=") {
+		t.Errorf("renderAsEnvFile: expected the nested object to be skipped, got %q", got)
+	}
+}
+
+// TestReportStaleVersions tests the reportStaleVersions function by reporting on a new
+// version with a tiny and a large maximum age, and verifies the version is only flagged
+// by the first. It runs against an in-memory server when no project is configured.
+func TestReportStaleVersions(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+
+	versionName, _ := setupTestParameterVersionWithClient(t, client, projectID, parameterID, "v1", "log_level=debug")
+
+	var buf bytes.Buffer
+	if err := reportStaleVersionsWithClient(ctx, client, &buf, projectID, parameterID, time.Nanosecond); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), fmt.Sprintf("Parameter version %s is", versionName); !strings.Contains(got, want) {
+		t.Errorf("reportStaleVersions: expected %q to contain %q", got, want)
+	}
+	if got, want := buf.String(), "Found 1 enabled parameter versions"; !strings.Contains(got, want) {
+		t.Errorf("reportStaleVersions: expected %q to contain %q", got, want)
+	}
+
+	buf.Reset()
+	if err := reportStaleVersionsWithClient(ctx, client, &buf, projectID, parameterID, 24*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); strings.Contains(got, versionName) {
+		t.Errorf("reportStaleVersions: expected no stale versions, got %q", got)
+	}
+	if got, want := buf.String(), "Found 0 enabled parameter versions"; !strings.Contains(got, want) {
+		t.Errorf("reportStaleVersions: expected %q to contain %q", got, want)
+	}
+}
+
+// TestCreateAndVerifyRender tests the createAndVerifyRender function with a version that
+// references a readable secret and one that references a missing secret, and verifies
+// the first resolves and the second fails.
+func TestCreateAndVerifyRender(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	secret := testSecret(t, projectID)
+	testSecretVersion(t, secret.Name, []byte("very secret data"))
+	if err := testIamGrantAccess(t, secret.Name, testGetParameter(t, parameterName).PolicyMember.IamPolicyUidPrincipal); err != nil {
+		t.Fatal(err)
+	}
+	resolvedID := testName(t)
+	missingID := testName(t)
+
+	defer testCleanupSecret(t, secret.Name)
+
+	payload := fmt.Sprintf(`{"password": "__REF__(//secretmanager.googleapis.com/%s/versions/latest)"}`, secret.Name)
+	var buf bytes.Buffer
+	if err := createAndVerifyRender(&buf, projectID, parameterID, resolvedID, []byte(payload)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "All 1 references resolved"; !strings.Contains(got, want) {
+		t.Errorf("createAndVerifyRender: expected %q to contain %q", got, want)
+	}
+
+	payload = fmt.Sprintf(`{"password": "__REF__(//secretmanager.googleapis.com/projects/%s/secrets/%s/versions/1)"}`, projectID, testName(t))
+	buf.Reset()
+	if err := createAndVerifyRender(&buf, projectID, parameterID, missingID, []byte(payload)); err == nil {
+		t.Error("createAndVerifyRender: expected an error for a missing secret")
+	}
+	if got := buf.String(); strings.Contains(got, "references resolved") {
+		t.Errorf("createAndVerifyRender: expected no success message, got %q", got)
+	}
+}
+
+// TestCreateAndVerifyRenderUnresolved tests the createAndVerifyRender function with a
+// server that returns references unresolved, and verifies the error lists them, including
+// one written with escaped quotes as in a JSON string. The
+// in-memory server echoes payloads when rendering, so it does not need a project.
+func TestCreateAndVerifyRenderUnresolved(t *testing.T) {
+	srv := newFakeParameterManagerServer("fake-project", 1)
+	client := newFakeServerClient(t, srv)
+	ctx := context.Background()
+
+	ref := "projects/fake-project/secrets/db-password/versions/1"
+	escapedRef := "projects/fake-project/secrets/api-key/versions/latest"
+	payload := []byte(`{"password": "__REF__(//secretmanager.googleapis.com/` + ref + `)", "key": "__REF__(\"//secretmanager.googleapis.com/` + escapedRef + `\")"}`)
+	err := createAndVerifyRenderWithClient(ctx, client, io.Discard, "fake-project", "parameter-0", "v1", payload)
+	if err == nil {
+		t.Fatal("createAndVerifyRender: expected an error for an unresolved reference")
+	}
+	if got, want := err.Error(), "unresolved secret references: "+ref+", "+escapedRef; !strings.Contains(got, want) {
+		t.Errorf("createAndVerifyRender: expected %q to contain %q", got, want)
+	}
+}
+
+// TestPatchVersion tests the patchVersion function by replacing a field of a JSON version
+// and verifies the new version holds the changed field, and that a patch targeting a
+// missing path fails. It runs against an in-memory server when no project is configured.
+func TestPatchVersion(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	setupTestParameterVersionWithClient(t, client, projectID, parameterID, "base", `{"db": {"host": "db-1", "port": 5432}, "replicas": ["a", "b"]}`)
+
+	patch := []byte(`[
+		{"op": "replace", "path": "/db/host", "value": "db-2"},
+		{"op": "add", "path": "/replicas/-", "value": "c"}
+	]`)
+	var buf bytes.Buffer
+	if err := patchVersionWithClient(ctx, client, &buf, projectID, parameterID, "base", "patched", patch); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Created patched parameter version: "+parent+"/versions/patched"; !strings.Contains(got, want) {
+		t.Errorf("patchVersion: expected %q to contain %q", got, want)
+	}
+
+	version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: parent + "/versions/patched",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		DB struct {
+			Host string `json:"host"`
+			Port int    `json:"port"`
+		} `json:"db"`
+		Replicas []string `json:"replicas"`
+	}
+	if err := json.Unmarshal(version.GetPayload().GetData(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.DB.Host != "db-2" || got.DB.Port != 5432 {
+		t.Errorf("patchVersion: expected db host db-2 and port 5432, got %+v", got.DB)
+	}
+	if want := []string{"a", "b", "c"}; !slices.Equal(got.Replicas, want) {
+		t.Errorf("patchVersion: expected replicas %v, got %v", want, got.Replicas)
+	}
+
+	err = patchVersionWithClient(ctx, client, io.Discard, projectID, parameterID, "base", "missing", []byte(`[{"op": "replace", "path": "/db/user", "value": "admin"}]`))
+	if err == nil {
+		t.Fatal("patchVersion: expected an error for a missing path")
+	}
+	if !errors.Is(err, jsonpatch.ErrMissing) {
+		t.Errorf("patchVersion: expected jsonpatch.ErrMissing, got %v", err)
+	}
+	if got, want := err.Error(), "/db/user"; !strings.Contains(got, want) {
+		t.Errorf("patchVersion: expected %q to contain %q", got, want)
+	}
+}
+
+// TestRotateReferencedSecret tests the rotateReferencedSecret function by rotating a secret
+// that a parameter version references, and verifies the render was confirmed before the
+// old secret version was disabled and that the parameter now renders the new value.
+func TestRotateReferencedSecret(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	secret := testSecret(t, projectID)
+	oldVersion := testSecretVersion(t, secret.Name, []byte("old secret data"))
+	if err := testIamGrantAccess(t, secret.Name, testGetParameter(t, parameterName).PolicyMember.IamPolicyUidPrincipal); err != nil {
+		t.Fatal(err)
+	}
+	payload := fmt.Sprintf(`{"password": "__REF__(//secretmanager.googleapis.com/%s/versions/latest)"}`, secret.Name)
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+
+	defer testCleanupSecret(t, secret.Name)
+
+	secretID := secret.Name[strings.LastIndex(secret.Name, "/")+1:]
+	var buf bytes.Buffer
+	if err := rotateReferencedSecret(&buf, projectID, parameterID, parameterVersionID, secretID, []byte("new secret data")); err != nil {
+		t.Fatal(err)
+	}
+
+	got := buf.String()
+	confirmed := strings.Index(got, "Confirmed parameter version")
+	disabled := strings.Index(got, "Disabled previous secret version "+oldVersion.Name)
+	if confirmed < 0 || disabled < 0 || confirmed > disabled {
+		t.Errorf("rotateReferencedSecret: expected the render to be confirmed before %s was disabled, got %q", oldVersion.Name, got)
+	}
+
+	buf.Reset()
+	if err := renderParamVersion(&buf, projectID, parameterID, parameterVersionID); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), `"password": "new secret data"`; !strings.Contains(got, want) {
+		t.Errorf("rotateReferencedSecret: expected %q to contain %q", got, want)
+	}
+
+	ctx := context.Background()
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	version, err := client.GetSecretVersion(ctx, &secretmanagerpb.GetSecretVersionRequest{Name: oldVersion.Name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.State != secretmanagerpb.SecretVersion_DISABLED {
+		t.Errorf("rotateReferencedSecret: expected %s to be disabled, got %s", oldVersion.Name, version.State)
+	}
+}
+
+// TestDetectSchemaDrift tests the detectSchemaDrift function on three versions where one
+// drops a key, and verifies only that version is reported with the key named as missing.
+// It runs against an in-memory server when no project is configured.
+func TestDetectSchemaDrift(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_JSON)
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	for _, v := range []struct{ id, payload string }{
+		{"v1", `{"host": "db-1", "port": 5432}`},
+		{"v2", `{"host": "db-2"}`},
+		{"v3", `{"host": "db-3", "port": 6543}`},
+	} {
+		setupTestParameterVersionWithClient(t, client, projectID, parameterID, v.id, v.payload)
+		// Space the versions out so their creation times are distinct.
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var buf bytes.Buffer
+	if err := detectSchemaDriftWithClient(ctx, client, &buf, projectID, parameterID); err != nil {
+		t.Fatal(err)
+	}
+
+	got := buf.String()
+	if want := fmt.Sprintf("Parameter version %s/versions/v2 has different keys", parent); !strings.Contains(got, want) {
+		t.Errorf("detectSchemaDrift: expected %q to contain %q", got, want)
+	}
+	if want := "missing: port"; !strings.Contains(got, want) {
+		t.Errorf("detectSchemaDrift: expected %q to contain %q", got, want)
+	}
+	if strings.Contains(got, parent+"/versions/v1 has") {
+		t.Errorf("detectSchemaDrift: expected v1 not to be reported, got %q", got)
+	}
+	if want := "Found 1 of 2 enabled parameter versions"; !strings.Contains(got, want) {
+		t.Errorf("detectSchemaDrift: expected %q to contain %q", got, want)
+	}
+}
+
+// TestExpiringVersions tests the createExpiringVersion and reapExpiredVersions functions by
+// creating a version with a 1ns TTL and reaping it once the expiry has passed, and
+// verifies a dry run leaves it enabled while a real run disables it and drops its expiry
+// label. It runs against an in-memory server when no project is configured.
+func TestExpiringVersions(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	versionName := parent + "/versions/v1"
+
+	var buf bytes.Buffer
+	if err := createExpiringVersionWithClient(ctx, client, &buf, projectID, parameterID, "v1", []byte("temporary"), time.Nanosecond); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Created parameter version "+versionName+" expiring at"; !strings.Contains(got, want) {
+		t.Errorf("createExpiringVersion: expected %q to contain %q", got, want)
+	}
+
+	// The expiry is rounded up to the next second, so reap as of a second from now.
+	now := time.Now().Add(time.Second)
+	buf.Reset()
+	if err := reapExpiredVersionsWithClient(ctx, client, &buf, projectID, parameterID, now, true); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Would disable parameter version "+versionName; !strings.Contains(got, want) {
+		t.Errorf("reapExpiredVersions: expected %q to contain %q", got, want)
+	}
+	version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{Name: versionName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.Disabled {
+		t.Error("reapExpiredVersions: expected a dry run to leave the version enabled")
+	}
+
+	buf.Reset()
+	if err := reapExpiredVersionsWithClient(ctx, client, &buf, projectID, parameterID, now, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Disabled 1 expired parameter versions"; !strings.Contains(got, want) {
+		t.Errorf("reapExpiredVersions: expected %q to contain %q", got, want)
+	}
+	version, err = client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{Name: versionName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !version.Disabled {
+		t.Error("reapExpiredVersions: expected the expired version to be disabled")
+	}
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{Name: parent})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := parameter.Labels[expiresAtLabelPrefix+"v1"]; ok {
+		t.Errorf("reapExpiredVersions: expected the expiry label to be removed, got %s", value)
+	}
+}
+
+// TestLoadParamBundle tests the loadParamBundle function on a parameter with two
+// renderable versions and one disabled version, and verifies the bundle holds the
+// parameter and both rendered payloads. It runs against an in-memory server
+// when no project is configured.
+func TestLoadParamBundle(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	for _, v := range []struct {
+		id, payload string
+		disabled    bool
+	}{
+		{"v1", "first", false},
+		{"v2", "second", false},
+		{"v3", "disabled", true},
+	} {
+		_, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+			Parent:             parent,
+			ParameterVersionId: v.id,
+			ParameterVersion: &parametermanagerpb.ParameterVersion{
+				Disabled: v.disabled,
+				Payload: &parametermanagerpb.ParameterVersionPayload{
+					Data: []byte(v.payload),
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bundle, err := loadParamBundleWithClient(ctx, client, projectID, parameterID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundle.Parameter.GetName() != parent {
+		t.Errorf("loadParamBundle: expected parameter %s, got %s", parent, bundle.Parameter.GetName())
+	}
+	got := make(map[string]string)
+	for _, version := range bundle.Versions {
+		got[version.Name] = string(version.RenderedPayload)
+	}
+	want := map[string]string{
+		parent + "/versions/v1": "first",
+		parent + "/versions/v2": "second",
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("loadParamBundle: expected rendered versions %v, got %v", want, got)
+	}
+}
+
+// TestPreflightKmsAccess tests the preflightKmsAccess function on a new key without and
+// then with the Parameter Manager service agent bound to the encrypter/decrypter role,
+// and verifies it reports false with a hint and then true.
+func TestPreflightKmsAccess(t *testing.T) {
+	tc := testutil.SystemTest(t)
+	ctx := context.Background()
+
+	testCreateKeyRing(t, tc.ProjectID, "go-test-key-ring")
+	keyID := testName(t)
+	testCreateKeyHSM(t, tc.ProjectID, "go-test-key-ring", keyID)
+	kmsKey := fmt.Sprintf("projects/%s/locations/global/keyRings/go-test-key-ring/cryptoKeys/%s", tc.ProjectID, keyID)
+	defer testCleanupKeyVersions(t, fmt.Sprintf("%s/cryptoKeyVersions/1", kmsKey))
+
+	var buf bytes.Buffer
+	ok, err := preflightKmsAccess(&buf, tc.ProjectID, kmsKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Errorf("preflightKmsAccess: expected false for a key without the binding, got %q", buf.String())
+	}
+	if got, want := buf.String(), "gcloud kms keys add-iam-policy-binding "+kmsKey; !strings.Contains(got, want) {
+		t.Errorf("preflightKmsAccess: expected %q to contain %q", got, want)
+	}
+
+	crm, err := cloudresourcemanager.NewService(ctx)
+	if err != nil {
+		t.Fatalf("failed to create Resource Manager client: %v", err)
+	}
+	project, err := crm.Projects.Get(tc.ProjectID).Context(ctx).Do()
+	if err != nil {
+		t.Fatalf("failed to get project: %v", err)
+	}
+	member := fmt.Sprintf("serviceAccount:service-%d@gcp-sa-pm.iam.gserviceaccount.com", project.ProjectNumber)
+
+	client, err := kms.NewKeyManagementClient(ctx)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+	handle := client.ResourceIAM(kmsKey)
+	policy, err := handle.Policy(ctx)
+	if err != nil {
+		t.Fatalf("failed to get policy: %v", err)
+	}
+	policy.Add(member, iam.RoleName(kmsEncrypterDecrypterRole))
+	if err := handle.SetPolicy(ctx, policy); err != nil {
+		t.Fatalf("failed to set policy: %v", err)
+	}
+
+	buf.Reset()
+	ok, err = preflightKmsAccess(&buf, tc.ProjectID, kmsKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("preflightKmsAccess: expected true for a key with the binding, got %q", buf.String())
+	}
+}
+
+// TestRenderRedacted tests the renderRedacted function on a version mixing a plain field
+// and a secret reference, and verifies the secret is masked and the plain field intact.
+func TestRenderRedacted(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	parameterName := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	secret := testSecret(t, projectID)
+	testSecretVersion(t, secret.Name, []byte("very secret data"))
+	payload := fmt.Sprintf(`{"username": "test-user","password": "__REF__(//secretmanager.googleapis.com/%s/versions/latest)"}`, secret.Name)
+	if err := testIamGrantAccess(t, secret.Name, testGetParameter(t, parameterName).PolicyMember.IamPolicyUidPrincipal); err != nil {
+		t.Fatal(err)
+	}
+	parameterVersionID, _ := setupTestParameterVersion(t, projectID, parameterID, payload)
+
+	defer testCleanupSecret(t, secret.Name)
+
+	var buf bytes.Buffer
+	if err := renderRedacted(&buf, projectID, parameterID, parameterVersionID); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), `Redacted payload: {"username": "test-user","password": "***"}`; !strings.Contains(got, want) {
+		t.Errorf("renderRedacted: expected %q to contain %q", got, want)
+	}
+	if got := buf.String(); strings.Contains(got, "very secret data") {
+		t.Errorf("renderRedacted: expected the secret to be masked, got %q", got)
+	}
+}
+
+// TestRedactRendered tests the redactRendered function with several references, adjacent
+// references, the escaped-quote reference written by createParamVersionWithSecretRef, and
+// a rendered payload that does not match the stored one.
+func TestRedactRendered(t *testing.T) {
+	ref := func(name string) string {
+		return "__REF__(//secretmanager.googleapis.com/projects/p/secrets/" + name + "/versions/1)"
+	}
+	for _, tc := range []struct {
+		name, stored, rendered, want string
+		wantErr                      bool
+	}{
+		{
+			name:     "no references",
+			stored:   "host=db",
+			rendered: "host=db",
+			want:     "host=db",
+		},
+		{
+			name:     "two references",
+			stored:   "user=" + ref("user") + " password=" + ref("password") + "\n",
+			rendered: "user=admin password=hunter2\n",
+			want:     "user=*** password=***\n",
+		},
+		{
+			name:     "adjacent references",
+			stored:   "key=" + ref("a") + ref("b"),
+			rendered: "key=firstsecond",
+			want:     "key=******",
+		},
+		{
+			name:     "escaped quotes",
+			stored:   `{"db_password": "__REF__(\"//secretmanager.googleapis.com/projects/p/secrets/s/versions/latest\")"}`,
+			rendered: `{"db_password": "hunter2"}`,
+			want:     `{"db_password": "***"}`,
+		},
+		{
+			name:     "mismatched text",
+			stored:   "user=" + ref("user"),
+			rendered: "name=admin",
+			wantErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := redactRendered([]byte(tc.stored), []byte(tc.rendered))
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("redactRendered: expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("redactRendered: expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestMigrateToJSON tests the migrateToJSON function on an UNFORMATTED parameter with two
+// JSON versions, and verifies a dry run passes without changing the format, that an
+// added non-JSON version blocks the migration, and that once it is deleted the parameter
+// is migrated. It runs against an in-memory server when no project is configured.
+func TestMigrateToJSON(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	createVersion := func(id, payload string) string {
+		t.Helper()
+		name, _ := setupTestParameterVersionWithClient(t, client, projectID, parameterID, id, payload)
+		return name
+	}
+	format := func() parametermanagerpb.ParameterFormat {
+		t.Helper()
+		parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{Name: parent})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parameter.Format
+	}
+
+	createVersion("v1", `{"host": "db-1"}`)
+	createVersion("v2", `{"host": "db-2", "port": 5432}`)
+
+	var buf bytes.Buffer
+	if err := migrateToJSONWithClient(ctx, client, &buf, projectID, parameterID, true); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "PASS "+parent+"/versions/v2"; !strings.Contains(got, want) {
+		t.Errorf("migrateToJSON: expected %q to contain %q", got, want)
+	}
+	if got := format(); got != parametermanagerpb.ParameterFormat_UNFORMATTED {
+		t.Errorf("migrateToJSON: expected a dry run to leave the format UNFORMATTED, got %s", got)
+	}
+
+	blocking := createVersion("v3", "host=db-3")
+	buf.Reset()
+	if err := migrateToJSONWithClient(ctx, client, &buf, projectID, parameterID, false); err == nil {
+		t.Error("migrateToJSON: expected a non-JSON version to block the migration")
+	}
+	if got, want := buf.String(), "FAIL "+blocking; !strings.Contains(got, want) {
+		t.Errorf("migrateToJSON: expected %q to contain %q", got, want)
+	}
+	if got := format(); got != parametermanagerpb.ParameterFormat_UNFORMATTED {
+		t.Errorf("migrateToJSON: expected a blocked migration to leave the format UNFORMATTED, got %s", got)
+	}
+
+	if err := client.DeleteParameterVersion(ctx, &parametermanagerpb.DeleteParameterVersionRequest{Name: blocking}); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := migrateToJSONWithClient(ctx, client, &buf, projectID, parameterID, false); err != nil {
+		t.Fatal(err)
+	}
+	if got := format(); got != parametermanagerpb.ParameterFormat_JSON {
+		t.Errorf("migrateToJSON: expected format JSON, got %s", got)
+	}
+}
+
+// TestListUsedKmsKeys tests the listUsedKmsKeys function on two parameters sharing a key
+// and one without a key, and verifies the count printed for each. It runs against an
+// in-memory server, which does not check that the key exists.
+func TestListUsedKmsKeys(t *testing.T) {
+	srv := newFakeParameterManagerServer("fake-project", 0)
+	client := newFakeServerClient(t, srv)
+	ctx := context.Background()
+
+	kmsKey := "projects/fake-project/locations/global/keyRings/ring/cryptoKeys/key"
+	for _, p := range []struct{ id, kmsKey string }{
+		{"with-key-1", kmsKey},
+		{"with-key-2", kmsKey},
+		{"default", ""},
+	} {
+		parameter := &parametermanagerpb.Parameter{}
+		if p.kmsKey != "" {
+			parameter.KmsKey = &p.kmsKey
+		}
+		if _, err := client.CreateParameter(ctx, &parametermanagerpb.CreateParameterRequest{
+			Parent:      "projects/fake-project/locations/global",
+			ParameterId: p.id,
+			Parameter:   parameter,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := listUsedKmsKeysWithClient(ctx, client, &buf, "fake-project"); err != nil {
+		t.Fatal(err)
+	}
+
+	counts := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n")[1:] {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			counts[fields[0]] = fields[1]
+		}
+	}
+	want := map[string]string{kmsKey: "2", "Google-managed": "1"}
+	if !maps.Equal(counts, want) {
+		t.Errorf("listUsedKmsKeys: expected counts %v, got %v in %q", want, counts, buf.String())
+	}
+}
+
+// TestConfigServer tests the ConfigServer handler with httptest on a JSON parameter, and
+// verifies the rendered body and content type, a 404 for an unknown parameter, and a 500
+// without the error details for a version that cannot be rendered. It runs against an
+// in-memory server when no project is configured.
+func TestConfigServer(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID, _ := setupTestParameterWithClient(t, client, projectID, parametermanagerpb.ParameterFormat_JSON)
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	payload := `{"host": "db", "port": 5432}`
+	for _, v := range []struct {
+		id       string
+		disabled bool
+	}{
+		{"v1", false},
+		{"disabled", true},
+	} {
+		_, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+			Parent:             parent,
+			ParameterVersionId: v.id,
+			ParameterVersion: &parametermanagerpb.ParameterVersion{
+				Disabled: v.disabled,
+				Payload: &parametermanagerpb.ParameterVersionPayload{
+					Data: []byte(payload),
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	srv := httptest.NewServer(NewConfigServer(client, projectID, time.Minute).Handler())
+	defer srv.Close()
+
+	get := func(path string) (*http.Response, string) {
+		t.Helper()
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp, string(body)
+	}
+
+	resp, body := get("/config/" + parameterID + "/v1")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ConfigServer: expected status 200, got %d: %s", resp.StatusCode, body)
+	}
+	if body != payload {
+		t.Errorf("ConfigServer: expected body %q, got %q", payload, body)
+	}
+	if got, want := resp.Header.Get("Content-Type"), "application/json"; got != want {
+		t.Errorf("ConfigServer: expected Content-Type %q, got %q", want, got)
+	}
+
+	if resp, body := get("/config/" + testName(t) + "/v1"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("ConfigServer: expected status 404 for an unknown parameter, got %d: %s", resp.StatusCode, body)
+	}
+
+	resp, body = get("/config/" + parameterID + "/disabled")
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("ConfigServer: expected status 500 for a disabled version, got %d: %s", resp.StatusCode, body)
+	}
+	if got, want := strings.TrimSpace(body), "failed to render parameter version"; got != want {
+		t.Errorf("ConfigServer: expected body %q, got %q", want, got)
+	}
+}
+
+// TestCreateVersionWithHashLabel tests the createVersionWithHashLabel function by creating
+// two versions with different payloads, and verifies the label follows the newest
+// payload's hash while other labels are kept. It runs against an in-memory server when
+// no project is configured.
+func TestCreateVersionWithHashLabel(t *testing.T) {
+	projectID, client := testProjectClient(t)
+	ctx := context.Background()
+
+	parameterID := testName(t)
+	parameter, err := client.CreateParameter(ctx, &parametermanagerpb.CreateParameterRequest{
+		Parent:      fmt.Sprintf("projects/%s/locations/global", projectID),
+		ParameterId: parameterID,
+		Parameter: &parametermanagerpb.Parameter{
+			Labels: map[string]string{"team": "platform"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	onceCleanup(t, func() { testDeleteParameterTreeWithClient(t, client, parameter.Name) })
+
+	for _, v := range []struct{ id, payload string }{
+		{"v1", "log_level=debug"},
+		{"v2", "log_level=info"},
+	} {
+		var buf bytes.Buffer
+		if err := createVersionWithHashLabelWithClient(ctx, client, &buf, projectID, parameterID, v.id, []byte(v.payload)); err != nil {
+			t.Fatal(err)
+		}
+
+		sum := sha256.Sum256([]byte(v.payload))
+		want := fmt.Sprintf("%x", sum)[:12]
+		if got := buf.String(); !strings.Contains(got, "latest_hash="+want) {
+			t.Errorf("createVersionWithHashLabel: expected %q to contain the hash %s", got, want)
+		}
+		updated, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{Name: parameter.Name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := updated.Labels["latest_hash"]; got != want {
+			t.Errorf("createVersionWithHashLabel: expected latest_hash %s after %s, got %s", want, v.id, got)
+		}
+		if got := updated.Labels["team"]; got != "platform" {
+			t.Errorf("createVersionWithHashLabel: expected the team label to be kept, got %q", got)
+		}
+	}
+}
+
+// TestReconcileProjects tests the reconcileProjects function with parameters that exist
+// in one project only, in both with the same payload, and in both with different
+// payloads, and verifies each is reported in the right category. It is skipped unless
+// GOLANG_SAMPLES_SECONDARY_PROJECT_ID names the second project.
+func TestReconcileProjects(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	projectBID := os.Getenv("GOLANG_SAMPLES_SECONDARY_PROJECT_ID")
+	if projectBID == "" {
+		t.Skip("GOLANG_SAMPLES_SECONDARY_PROJECT_ID not set")
+	}
+
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	// createParam creates a parameter with one version holding payload.
+	createParam := func(projectID, parameterID, payload string) {
+		t.Helper()
+		parameter, err := client.CreateParameter(ctx, &parametermanagerpb.CreateParameterRequest{
+			Parent:      fmt.Sprintf("projects/%s/locations/global", projectID),
+			ParameterId: parameterID,
+			Parameter:   &parametermanagerpb.Parameter{},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		onceCleanup(t, func() { testDeleteParameterTreeWithClient(t, client, parameter.Name) })
+		setupTestParameterVersionWithClient(t, client, projectID, parameterID, "v1", payload)
+	}
+
+	onlyA, onlyB, same, differ := testName(t), testName(t), testName(t), testName(t)
+	createParam(tc.ProjectID, onlyA, "a")
+	createParam(projectBID, onlyB, "b")
+	createParam(tc.ProjectID, same, "same")
+	createParam(projectBID, same, "same")
+	createParam(tc.ProjectID, differ, "staging")
+	createParam(projectBID, differ, "production")
+
+	var buf bytes.Buffer
+	if err := reconcileProjects(&buf, tc.ProjectID, projectBID); err != nil {
+		t.Fatal(err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{
+		fmt.Sprintf("Parameter %s exists only in %s", onlyA, tc.ProjectID),
+		fmt.Sprintf("Parameter %s exists only in %s", onlyB, projectBID),
+		fmt.Sprintf("Parameter %s differs between %s and %s", differ, tc.ProjectID, projectBID),
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("reconcileProjects: expected %q to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "Parameter "+same+" ") {
+		t.Errorf("reconcileProjects: expected %s not to be reported, got %q", same, got)
+	}
+}
+
+// TestConfigLoaderWatcherFails tests that NewConfigLoader returns the watcher's error
+// when the watcher stops before the first payload, even with a context that has no
+// deadline. An invalid interval makes the watcher fail at once.
+func TestConfigLoaderWatcherFails(t *testing.T) {
+	errc := make(chan error, 1)
+	go func() {
+		_, err := NewConfigLoader(context.Background(), "fake-project", "parameter-0", 0)
+		errc <- err
+	}()
+
+	select {
+	case err := <-errc:
+		if err == nil || !strings.Contains(err.Error(), "invalid polling interval") {
+			t.Errorf("NewConfigLoader: expected the watcher's error, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("NewConfigLoader: timed out waiting for the watcher failure to be reported")
+	}
+}
+
+// TestConfigLoader tests the ConfigLoader type by loading a parameter with one version,
+// adding a second version, and verifying Get returns each payload in turn and Close stops
+// the watcher.
+func TestConfigLoader(t *testing.T) {
+	projectID, parameterID, _ := setupTestParameter(t, parametermanagerpb.ParameterFormat_JSON)
+	first := `{"version": 1}`
+	setupTestParameterVersion(t, projectID, parameterID, first)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	loader, err := NewConfigLoader(ctx, projectID, parameterID, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(loader.Get()); got != first {
+		t.Errorf("ConfigLoader: expected initial payload %q, got %q", first, got)
+	}
+
+	second := `{"version": 2}`
+	setupTestParameterVersion(t, projectID, parameterID, second)
+
+	deadline := time.Now().Add(30 * time.Second)
+	for string(loader.Get()) != second {
+		if time.Now().After(deadline) {
+			t.Fatalf("ConfigLoader: timed out waiting for payload %q, last got %q", second, loader.Get())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	closeCtx, closeCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer closeCancel()
+	if err := loader.Close(closeCtx); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-loader.done:
+	default:
+		t.Error("ConfigLoader: expected the watcher goroutine to have exited after Close")
+	}
+}
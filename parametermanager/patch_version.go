@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_patch_version]
+import (
+	"context"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// patchVersion applies an RFC 6902 JSON Patch to the payload of a parameter version and
+// stores the result as a new version, using the Parameter Manager SDK for GCP. This
+// changes individual fields without resending the whole payload.
+//
+// The stored payload is patched rather than the rendered one, so secret references are
+// copied into the new version unresolved. The patch is applied with the
+// github.com/evanphx/json-patch/v5 package, in full or not at all.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter holding the versions.
+// baseVersionID: The ID of the version to be patched.
+// newVersionID: The ID of the version to be created with the patched payload.
+// patch: The JSON Patch document, a JSON array of operations.
+//
+// The function returns an error if the base version cannot be read or is not JSON, the
+// patch is invalid or an operation targets a missing path, or the version creation fails.
+func patchVersion(w io.Writer, projectID, parameterID, baseVersionID, newVersionID string, patch []byte) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return patchVersionWithClient(ctx, client, w, projectID, parameterID, baseVersionID, newVersionID, patch)
+}
+
+// patchVersionWithClient reads the stored payload of the base version through client,
+// applies patch to it, and creates the new version from the result.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter holding the versions.
+// baseVersionID: The ID of the version to be patched.
+// newVersionID: The ID of the version to be created with the patched payload.
+// patch: The JSON Patch document, a JSON array of operations.
+//
+// The function returns an error if the base version cannot be read or is not JSON, the
+// patch is invalid or an operation targets a missing path, or the version creation fails.
+func patchVersionWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, baseVersionID, newVersionID string, patch []byte) error {
+	p, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return fmt.Errorf("failed to decode JSON patch: %w", err)
+	}
+
+	// Read the stored payload of the base version.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	base, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: fmt.Sprintf("%s/versions/%s", name, baseVersionID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter version %s: %w", baseVersionID, err)
+	}
+
+	// Apply the patch to a copy of the payload.
+	payload, err := p.Apply(base.GetPayload().GetData())
+	if err != nil {
+		return fmt.Errorf("failed to apply JSON patch to version %s: %w", baseVersionID, err)
+	}
+
+	// Call the API to create the parameter version with the patched payload.
+	version, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             name,
+		ParameterVersionId: newVersionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	fmt.Fprintf(w, "Created patched parameter version: %s\n", version.Name)
+	return nil
+}
+
+// [END parametermanager_patch_version]
@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_preflight_kms]
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/iam"
+	kms "cloud.google.com/go/kms/apiv1"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
+)
+
+// kmsEncrypterDecrypterRole is the role the Parameter Manager service agent needs on a
+// Cloud KMS key to protect parameters with it.
+const kmsEncrypterDecrypterRole = "roles/cloudkms.cryptoKeyEncrypterDecrypter"
+
+// preflightKmsAccess checks whether the Parameter Manager service agent of a project can
+// use a Cloud KMS key, before the key is set on a parameter. Without the
+// roles/cloudkms.cryptoKeyEncrypterDecrypter role on the key, creating or updating a
+// parameter with the key fails.
+//
+// The service agent is service-PROJECT_NUMBER@gcp-sa-pm.iam.gserviceaccount.com, so the
+// project number is looked up first. Only the key's own IAM policy is checked; a grant on
+// the key ring or project is not seen, so false means the role could not be confirmed.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project whose parameters will use the key.
+// kmsKey: The resource name of the Cloud KMS key.
+//
+// The function returns whether the service agent holds the role on the key, or an error
+// if the project or the key's IAM policy cannot be read.
+func preflightKmsAccess(w io.Writer, projectID, kmsKey string) (bool, error) {
+	// kmsKey := "projects/my-project/locations/global/keyRings/my-ring/cryptoKeys/my-key"
+	ctx := context.Background()
+
+	// Look up the project number, which names the service agent.
+	crm, err := cloudresourcemanager.NewService(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to create Resource Manager client: %w", err)
+	}
+	project, err := crm.Projects.Get(projectID).Context(ctx).Do()
+	if err != nil {
+		return false, fmt.Errorf("failed to get project: %w", err)
+	}
+	member := fmt.Sprintf("serviceAccount:service-%d@gcp-sa-pm.iam.gserviceaccount.com", project.ProjectNumber)
+
+	// Create a Cloud KMS client and read the key's IAM policy.
+	client, err := kms.NewKeyManagementClient(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to create KMS client: %w", err)
+	}
+	defer client.Close()
+
+	policy, err := client.ResourceIAM(kmsKey).Policy(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get IAM policy of %s: %w", kmsKey, err)
+	}
+
+	if !policy.HasRole(member, iam.RoleName(kmsEncrypterDecrypterRole)) {
+		fmt.Fprintf(w, "%s does not have %s on %s\n", member, kmsEncrypterDecrypterRole, kmsKey)
+		fmt.Fprintf(w, "Grant it before setting the key on a parameter:\n")
+		fmt.Fprintf(w, "  gcloud kms keys add-iam-policy-binding %s --member=%s --role=%s\n", kmsKey, member, kmsEncrypterDecrypterRole)
+		return false, nil
+	}
+	fmt.Fprintf(w, "%s has %s on %s\n", member, kmsEncrypterDecrypterRole, kmsKey)
+	return true, nil
+}
+
+// [END parametermanager_preflight_kms]
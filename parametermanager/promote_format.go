@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_promote_format]
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"gopkg.in/yaml.v3"
+)
+
+// validateForFormat checks that payload is acceptable for a parameter of the given format.
+// Any payload is acceptable for an UNFORMATTED parameter.
+func validateForFormat(payload []byte, format parametermanagerpb.ParameterFormat) error {
+	switch format {
+	case parametermanagerpb.ParameterFormat_JSON:
+		if !json.Valid(payload) {
+			return errors.New("payload is not valid JSON")
+		}
+	case parametermanagerpb.ParameterFormat_YAML:
+		// A YAML parameter holds exactly one well-formed document.
+		dec := yaml.NewDecoder(bytes.NewReader(payload))
+		var doc interface{}
+		if err := dec.Decode(&doc); errors.Is(err, io.EOF) {
+			return errors.New("payload is empty: a YAML document is required")
+		} else if err != nil {
+			return fmt.Errorf("payload is not valid YAML: %w", err)
+		}
+		if err := dec.Decode(&doc); !errors.Is(err, io.EOF) {
+			return errors.New("payload contains multiple YAML documents: only a single document is allowed")
+		}
+	}
+	return nil
+}
+
+// promoteToFormat copies a parameter version into another parameter, checking that the
+// payload is valid for the destination parameter's format, using the Parameter Manager
+// SDK for GCP. This moves a value from an UNFORMATTED parameter into a JSON or YAML one.
+//
+// The stored payload is copied, so secret references are kept unresolved.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+// srcParameterID: The ID of the parameter to copy from.
+// srcVersionID: The ID of the version to copy.
+// dstParameterID: The ID of the parameter to copy to.
+// dstVersionID: The ID of the version to be created.
+//
+// The function returns an error if either parameter or the source version cannot be read,
+// the payload is not valid for the destination format, or the version creation fails.
+func promoteToFormat(w io.Writer, projectID, srcParameterID, srcVersionID, dstParameterID, dstVersionID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return promoteToFormatWithClient(ctx, client, w, projectID, srcParameterID, srcVersionID, dstParameterID, dstVersionID)
+}
+
+// promoteToFormatWithClient reads the source version and the destination parameter
+// through client, checks the payload with validateForFormat, and creates the copy.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+// srcParameterID: The ID of the parameter to copy from.
+// srcVersionID: The ID of the version to copy.
+// dstParameterID: The ID of the parameter to copy to.
+// dstVersionID: The ID of the version to be created.
+//
+// The function returns an error if either parameter or the source version cannot be read,
+// the payload is not valid for the destination format, or the version creation fails.
+func promoteToFormatWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, srcParameterID, srcVersionID, dstParameterID, dstVersionID string) error {
+	// Read the stored payload of the source version.
+	srcName := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, srcParameterID, srcVersionID)
+	src, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: srcName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get source parameter version: %w", err)
+	}
+	payload := src.GetPayload().GetData()
+
+	// Get the destination parameter for its format.
+	dstParent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, dstParameterID)
+	dst, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: dstParent,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get destination parameter: %w", err)
+	}
+	if err := validateForFormat(payload, dst.Format); err != nil {
+		return fmt.Errorf("cannot promote %s to %s parameter %s: %w", srcName, dst.Format, dstParent, err)
+	}
+
+	// Call the API to create the destination version with the same payload.
+	version, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             dstParent,
+		ParameterVersionId: dstVersionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	fmt.Fprintf(w, "Promoted %s to %s parameter version %s\n", srcName, dst.Format, version.Name)
+	return nil
+}
+
+// [END parametermanager_promote_format]
@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_prune_old_versions]
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// pruneOldVersions deletes all but the newest versions of a parameter using the Parameter
+// Manager SDK for GCP.
+//
+// Versions are ordered by creation time. An old version is kept anyway, with a notice,
+// if deleting it would leave the parameter without an enabled version to render.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose old versions are to be deleted.
+// keep: The number of newest versions to keep.
+// dryRun: Whether to only print the versions that would be deleted.
+//
+// The function returns an error if keep is negative or listing or deleting the versions fails.
+func pruneOldVersions(w io.Writer, projectID, parameterID string, keep int, dryRun bool) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return pruneOldVersionsWithClient(ctx, client, w, projectID, parameterID, keep, dryRun)
+}
+
+// pruneOldVersionsWithClient lists the versions through client, orders them by creation
+// time, and deletes all but the newest keep of them.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose old versions are to be deleted.
+// keep: The number of newest versions to keep.
+// dryRun: Whether to only print the versions that would be deleted.
+//
+// The function returns an error if keep is negative or listing or deleting the versions fails.
+func pruneOldVersionsWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID string, keep int, dryRun bool) error {
+	if keep < 0 {
+		return fmt.Errorf("keep must not be negative, got %d", keep)
+	}
+
+	// Construct the name of the parameter whose versions are listed.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Collect all versions, counting the enabled ones along the way.
+	enabled := 0
+	var all []*parametermanagerpb.ParameterVersion
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: parent,
+	})
+	for {
+		version, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		all = append(all, version)
+		if !version.Disabled {
+			enabled++
+		}
+	}
+	if len(all) <= keep {
+		fmt.Fprintf(w, "Pruned 0 parameter versions: %d versions, keeping up to %d\n", len(all), keep)
+		return nil
+	}
+
+	// Sort oldest first, so the versions to prune are at the front.
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].GetCreateTime().AsTime().Before(all[j].GetCreateTime().AsTime())
+	})
+
+	pruned := 0
+	for _, version := range all[:len(all)-keep] {
+		if !version.Disabled {
+			if enabled == 1 {
+				fmt.Fprintf(w, "Keeping parameter version %s because it is the only enabled version\n", version.Name)
+				continue
+			}
+			enabled--
+		}
+		if dryRun {
+			fmt.Fprintf(w, "Would delete parameter version %s\n", version.Name)
+			pruned++
+			continue
+		}
+		if err := client.DeleteParameterVersion(ctx, &parametermanagerpb.DeleteParameterVersionRequest{
+			Name: version.Name,
+		}); err != nil {
+			return fmt.Errorf("failed to delete parameter version %s: %w", version.Name, err)
+		}
+		fmt.Fprintf(w, "Deleted parameter version %s\n", version.Name)
+		pruned++
+	}
+
+	if dryRun {
+		fmt.Fprintf(w, "Would prune %d parameter versions\n", pruned)
+	} else {
+		fmt.Fprintf(w, "Pruned %d parameter versions\n", pruned)
+	}
+	return nil
+}
+
+// [END parametermanager_prune_old_versions]
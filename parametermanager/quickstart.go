@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_quickstart_end_to_end]
+import (
+	"context"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// quickstart walks through the lifecycle of a parameter using the Parameter Manager SDK for GCP:
+// it creates a JSON parameter, adds a version, renders it, and then deletes the version and the parameter.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is to be created.
+// parameterID: The ID of the parameter to be created.
+// versionID: The ID of the version to be created.
+//
+// The function returns an error naming the step that failed.
+func quickstart(w io.Writer, projectID, parameterID, versionID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Step 1: Create a parameter with the JSON format.
+	parameter, err := client.CreateParameter(ctx, &parametermanagerpb.CreateParameterRequest{
+		Parent:      fmt.Sprintf("projects/%s/locations/global", projectID),
+		ParameterId: parameterID,
+		Parameter: &parametermanagerpb.Parameter{
+			Format: parametermanagerpb.ParameterFormat_JSON,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create parameter: %w", err)
+	}
+	fmt.Fprintf(w, "Created parameter %s with format %s\n", parameter.Name, parameter.Format.String())
+
+	// Step 2: Create a parameter version with a JSON payload.
+	payload := []byte(`{"username": "test-user", "host": "localhost"}`)
+	version, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parameter.Name,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create parameter version: %w", err)
+	}
+	fmt.Fprintf(w, "Created parameter version: %s\n", version.Name)
+
+	// Step 3: Render the parameter version.
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: version.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("render parameter version: %w", err)
+	}
+	fmt.Fprintf(w, "Rendered payload: %s\n", rendered.RenderedPayload)
+
+	// Step 4: Delete the parameter version. A parameter cannot be deleted while it has versions.
+	if err := client.DeleteParameterVersion(ctx, &parametermanagerpb.DeleteParameterVersionRequest{
+		Name: version.Name,
+	}); err != nil {
+		return fmt.Errorf("delete parameter version: %w", err)
+	}
+	fmt.Fprintf(w, "Deleted parameter version: %s\n", version.Name)
+
+	// Step 5: Delete the parameter.
+	if err := client.DeleteParameter(ctx, &parametermanagerpb.DeleteParameterRequest{
+		Name: parameter.Name,
+	}); err != nil {
+		return fmt.Errorf("delete parameter: %w", err)
+	}
+	fmt.Fprintf(w, "Deleted parameter: %s\n", parameter.Name)
+
+	return nil
+}
+
+// [END parametermanager_quickstart_end_to_end]
@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_rate_limited_delete]
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"golang.org/x/time/rate"
+	"google.golang.org/api/iterator"
+)
+
+// waiter is the part of *rate.Limiter used by rateLimitedDelete.
+type waiter interface {
+	Wait(ctx context.Context) error
+}
+
+// rateLimitedDelete deletes parameters, and their versions first, at no more than perSecond
+// delete calls per second using the Parameter Manager SDK for GCP, to stay under the
+// write quota that large bulk deletes otherwise exhaust.
+//
+// Every DeleteParameterVersion and DeleteParameter call waits for the limiter. A failure
+// for one parameter does not stop the others.
+//
+// ctx: The context used for the API calls and for waiting on the limiter.
+// projectID: The ID of the project where the parameters are located.
+// parameterIDs: The IDs of the parameters to be deleted.
+// perSecond: The maximum number of delete calls per second.
+//
+// The function returns an error joining the failures of all parameters that could not
+// be deleted, or nil if every parameter was deleted.
+func rateLimitedDelete(ctx context.Context, projectID string, parameterIDs []string, perSecond float64) error {
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// A burst of 1 spaces the calls evenly instead of allowing an initial spike.
+	limiter := rate.NewLimiter(rate.Limit(perSecond), 1)
+	return rateLimitedDeleteWithClient(ctx, client, limiter, projectID, parameterIDs)
+}
+
+// rateLimitedDeleteWithClient deletes the parameters through client, waiting on limiter
+// before every delete call.
+//
+// ctx: The context used for the API calls and for waiting on the limiter.
+// client: The Parameter Manager client used for the API calls.
+// limiter: The limiter every delete call waits for, such as a *rate.Limiter.
+// projectID: The ID of the project where the parameters are located.
+// parameterIDs: The IDs of the parameters to be deleted.
+//
+// The function returns an error joining the failures of all parameters that could not
+// be deleted, or nil if every parameter was deleted.
+func rateLimitedDeleteWithClient(ctx context.Context, client ParameterClient, limiter waiter, projectID string, parameterIDs []string) error {
+	var errs []error
+	for _, parameterID := range parameterIDs {
+		name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+		if err := deleteParamThrottled(ctx, client, limiter, name); err != nil {
+			errs = append(errs, fmt.Errorf("parameter %s: %w", parameterID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deleteParamThrottled deletes a parameter's versions and then the parameter, waiting for
+// the limiter before each delete call.
+func deleteParamThrottled(ctx context.Context, client ParameterClient, limiter waiter, name string) error {
+	// Collect the version names before deleting, so the listing is not disturbed.
+	var versionNames []string
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: name,
+	})
+	for {
+		version, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		versionNames = append(versionNames, version.Name)
+	}
+
+	for _, versionName := range versionNames {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+		if err := client.DeleteParameterVersion(ctx, &parametermanagerpb.DeleteParameterVersionRequest{
+			Name: versionName,
+		}); err != nil {
+			return fmt.Errorf("failed to delete parameter version %s: %w", versionName, err)
+		}
+	}
+
+	if err := limiter.Wait(ctx); err != nil {
+		return err
+	}
+	if err := client.DeleteParameter(ctx, &parametermanagerpb.DeleteParameterRequest{
+		Name: name,
+	}); err != nil {
+		return fmt.Errorf("failed to delete parameter: %w", err)
+	}
+	return nil
+}
+
+// [END parametermanager_rate_limited_delete]
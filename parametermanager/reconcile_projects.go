@@ -0,0 +1,184 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_reconcile_projects]
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/iterator"
+)
+
+// reconcileProjects compares the parameters of two projects, such as staging and
+// production, using the Parameter Manager SDK for GCP. It prints the parameter IDs that
+// exist in only one of the projects, and those that exist in both but whose latest
+// enabled versions hold different payloads.
+//
+// The stored payloads are compared, so no secrets are accessed; a secret reference that
+// names a project-specific secret therefore shows up as a difference. At most 10
+// parameters are compared at a time.
+//
+// w: The io.Writer object used to write the output.
+// projectAID: The ID of the first project.
+// projectBID: The ID of the second project.
+//
+// The function returns an error if listing the parameters or reading a version fails.
+func reconcileProjects(w io.Writer, projectAID, projectBID string) error {
+	// Create a context and a Parameter Manager client shared by all the fetches.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// List the parameters of both projects at the same time.
+	var inA, inB map[string]bool
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		inA, err = listParameterIDs(gctx, client, projectAID)
+		return err
+	})
+	g.Go(func() (err error) {
+		inB, err = listParameterIDs(gctx, client, projectBID)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	var onlyA, onlyB, both []string
+	for _, id := range slices.Sorted(maps.Keys(inA)) {
+		if inB[id] {
+			both = append(both, id)
+		} else {
+			onlyA = append(onlyA, id)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(inB)) {
+		if !inA[id] {
+			onlyB = append(onlyB, id)
+		}
+	}
+
+	// Compare the latest payloads of the parameters in both projects.
+	g, gctx = errgroup.WithContext(ctx)
+	g.SetLimit(10)
+	var mu sync.Mutex
+	differ := make(map[string]bool)
+	for _, id := range both {
+		g.Go(func() error {
+			a, err := latestStoredPayload(gctx, client, projectAID, id)
+			if err != nil {
+				return err
+			}
+			b, err := latestStoredPayload(gctx, client, projectBID, id)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(a, b) {
+				mu.Lock()
+				differ[id] = true
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	for _, id := range onlyA {
+		fmt.Fprintf(w, "Parameter %s exists only in %s\n", id, projectAID)
+	}
+	for _, id := range onlyB {
+		fmt.Fprintf(w, "Parameter %s exists only in %s\n", id, projectBID)
+	}
+	for _, id := range both {
+		if differ[id] {
+			fmt.Fprintf(w, "Parameter %s differs between %s and %s\n", id, projectAID, projectBID)
+		}
+	}
+	fmt.Fprintf(w, "Found %d parameters only in %s, %d only in %s, and %d of %d shared parameters that differ\n",
+		len(onlyA), projectAID, len(onlyB), projectBID, len(differ), len(both))
+	return nil
+}
+
+// listParameterIDs returns the set of parameter IDs in the global location of a project.
+func listParameterIDs(ctx context.Context, client ParameterClient, projectID string) (map[string]bool, error) {
+	ids := make(map[string]bool)
+	parameters := client.ListParameters(ctx, &parametermanagerpb.ListParametersRequest{
+		Parent: fmt.Sprintf("projects/%s/locations/global", projectID),
+	})
+	for {
+		parameter, err := parameters.Next()
+		if err == iterator.Done {
+			return ids, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list parameters in %s: %w", projectID, err)
+		}
+		ids[parameter.Name[strings.LastIndex(parameter.Name, "/")+1:]] = true
+	}
+}
+
+// latestStoredPayload returns the stored payload of the newest enabled version of a
+// parameter, or nil if it has no enabled versions.
+func latestStoredPayload(ctx context.Context, client ParameterClient, projectID, parameterID string) ([]byte, error) {
+	// Keep the enabled version with the most recent creation time.
+	var latest *parametermanagerpb.ParameterVersion
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID),
+	})
+	for {
+		version, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		if version.Disabled {
+			continue
+		}
+		if latest == nil || version.GetCreateTime().AsTime().After(latest.GetCreateTime().AsTime()) {
+			latest = version
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+
+	// Listing does not return payloads, so fetch the version.
+	version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: latest.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parameter version %s: %w", latest.Name, err)
+	}
+	return version.GetPayload().GetData(), nil
+}
+
+// [END parametermanager_reconcile_projects]
@@ -32,10 +32,10 @@ import (
 // locationID: The ID of the region where the parameter is located.
 // parameterID: The ID of the parameter for which the version is to be created.
 // versionID: The ID of the version to be created.
-// payload: The unformatted string payload to be stored in the new parameter version.
+// payload: The unformatted payload bytes to be stored in the new parameter version.
 //
 // The function returns an error if the parameter version creation fails.
-func createRegionalParamVersion(w io.Writer, projectID, locationID, parameterID, versionID, payload string) error {
+func createRegionalParamVersion(w io.Writer, projectID, locationID, parameterID, versionID string, payload []byte) error {
 	// Create a context.
 	ctx := context.Background()
 
@@ -56,7 +56,7 @@ func createRegionalParamVersion(w io.Writer, projectID, locationID, parameterID,
 		ParameterVersionId: versionID,
 		ParameterVersion: &parametermanagerpb.ParameterVersion{
 			Payload: &parametermanagerpb.ParameterVersionPayload{
-				Data: []byte(payload),
+				Data: payload,
 			},
 		},
 	}
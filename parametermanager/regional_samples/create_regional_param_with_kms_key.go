@@ -23,10 +23,16 @@ import (
 	parametermanager "cloud.google.com/go/parametermanager/apiv1"
 	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
 	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // createRegionalParamWithKmsKey creates a regional parameter with kms_key using the Parameter Manager SDK for GCP.
 //
+// The key must be in the same location as the parameter, and the Parameter Manager
+// service agent of the project must be able to use it; otherwise the creation fails
+// with PermissionDenied.
+//
 // w: The io.Writer object used to write the output.
 // projectID: The ID of the project where the parameter is located.
 // locationID: The ID of the location where the parameter is located.
@@ -35,7 +41,7 @@ import (
 // (e.g. "projects/my-project/locations/us-central1/keyRings/my-key-ring/cryptoKeys/my-encryption-key")
 // (For more information, see: https://cloud.google.com/secret-manager/parameter-manager/docs/cmek)
 //
-// The function returns an error if the parameter creation fails.
+// The function returns an error if the service agent cannot use the key or the parameter creation fails.
 func createRegionalParamWithKmsKey(w io.Writer, projectID, locationID, parameterID, kmsKey string) error {
 	// Create a context and a Parameter Manager client.
 	ctx := context.Background()
@@ -62,6 +68,9 @@ func createRegionalParamWithKmsKey(w io.Writer, projectID, locationID, parameter
 	}
 	parameter, err := client.CreateParameter(ctx, req)
 	if err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			return fmt.Errorf("the Parameter Manager service agent cannot use kms_key %s: grant it roles/cloudkms.cryptoKeyEncrypterDecrypter on the key: %w", kmsKey, err)
+		}
 		return fmt.Errorf("failed to create parameter: %w", err)
 	}
 
@@ -23,6 +23,8 @@ import (
 	parametermanager "cloud.google.com/go/parametermanager/apiv1"
 	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
 	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // deleteRegionalParam deletes a regional parameter using the Parameter Manager SDK for GCP.
@@ -32,7 +34,7 @@ import (
 // locationID: The ID of the region where the parameter is located.
 // parameterID: The ID of the parameter to be deleted.
 //
-// The function returns an error if the parameter deletion fails.
+// The function returns an error if the parameter still has versions or the deletion fails.
 func deleteRegionalParam(w io.Writer, projectID, locationID, parameterID string) error {
 	// Create a new context.
 	ctx := context.Background()
@@ -55,6 +57,9 @@ func deleteRegionalParam(w io.Writer, projectID, locationID, parameterID string)
 
 	// Call the API to delete the parameter.
 	if err := client.DeleteParameter(ctx, req); err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return fmt.Errorf("regional parameter %s still has versions, delete its versions first: %w", parameterID, err)
+		}
 		return fmt.Errorf("failed to delete parameter: %w", err)
 	}
 
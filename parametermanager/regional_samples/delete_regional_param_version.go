@@ -23,6 +23,8 @@ import (
 	parametermanager "cloud.google.com/go/parametermanager/apiv1"
 	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
 	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // deleteRegionalParamVersion deletes a regional parameter version using the Parameter Manager SDK for GCP.
@@ -33,6 +35,8 @@ import (
 // parameterID: The ID of the parameter for which the version is to be deleted.
 // versionID: The ID of the version to be deleted.
 //
+// Deleting a version that does not exist is not treated as an error.
+//
 // The function returns an error if the parameter version deletion fails.
 func deleteRegionalParamVersion(w io.Writer, projectID, locationID, parameterID, versionID string) error {
 	// Create a new context.
@@ -56,6 +60,10 @@ func deleteRegionalParamVersion(w io.Writer, projectID, locationID, parameterID,
 
 	// Call the API to delete the parameter version.
 	if err := client.DeleteParameterVersion(ctx, req); err != nil {
+		if status.Code(err) == codes.NotFound {
+			fmt.Fprintf(w, "Regional parameter version %s not found, nothing to delete\n", name)
+			return nil
+		}
 		return fmt.Errorf("failed to delete parameter version: %w", err)
 	}
 
@@ -23,6 +23,8 @@ import (
 	parametermanager "cloud.google.com/go/parametermanager/apiv1"
 	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
 	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // getRegionalParam gets a parameter regional using the Parameter Manager SDK for GCP.
@@ -32,7 +34,7 @@ import (
 // locationID: The ID of the region where the parameter is located.
 // parameterID: The ID of the parameter to be retrieved.
 //
-// The function returns an error if the parameter retrieval fails.
+// The function returns an error if the parameter does not exist in the location or the retrieval fails.
 func getRegionalParam(w io.Writer, projectID, locationID, parameterID string) error {
 	// Create a new context.
 	ctx := context.Background()
@@ -56,12 +58,20 @@ func getRegionalParam(w io.Writer, projectID, locationID, parameterID string) er
 	// Call the API to get the parameter.
 	param, err := client.GetParameter(ctx, req)
 	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("regional parameter %s not found in location %s", parameterID, locationID)
+		}
 		return fmt.Errorf("failed to get parameter: %w", err)
 	}
 
 	// Find more details for the Parameter object here:
 	// https://cloud.google.com/secret-manager/parameter-manager/docs/reference/rest/v1/projects.locations.parameters#Parameter
 	fmt.Fprintf(w, "Found regional parameter %s with format %s\n", param.Name, param.Format.String())
+	if param.KmsKey != nil && *param.KmsKey != "" {
+		fmt.Fprintf(w, "Regional parameter is encrypted with kms_key %s\n", *param.KmsKey)
+	} else {
+		fmt.Fprintf(w, "Regional parameter is encrypted with a Google-managed key\n")
+	}
 	return nil
 }
 
@@ -26,14 +26,16 @@ import (
 	"google.golang.org/api/option"
 )
 
-// listRegionalParam lists all parameters regional using the Parameter Manager SDK for GCP.
+// listRegionalParams lists all regional parameters in a location using the Parameter Manager SDK for GCP.
+// The client talks to the location's regional endpoint and the parent is scoped to
+// the location, so every listed name contains "/locations/{locationID}/".
 //
-// projectID: The ID of the project where the parameter is located.
-// locationID: The ID of the region where the parameter is located.
-// parameterID: The ID of the parameter to be listed.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+// locationID: The ID of the region where the parameters are located.
 //
-// The function returns an error if the parameter listing fails
-func listRegionalParam(w io.Writer, projectID, locationID string) error {
+// The function returns an error if the parameter listing fails.
+func listRegionalParams(w io.Writer, projectID, locationID string) error {
 	// Create a new context.
 	ctx := context.Background()
 
@@ -54,6 +56,7 @@ func listRegionalParam(w io.Writer, projectID, locationID string) error {
 	}
 
 	// Call the API to list all parameters.
+	count := 0
 	parameters := client.ListParameters(ctx, req)
 	for {
 		parameter, err := parameters.Next()
@@ -65,8 +68,10 @@ func listRegionalParam(w io.Writer, projectID, locationID string) error {
 		}
 
 		fmt.Fprintf(w, "Found regional parameter %s with format %s\n", parameter.Name, parameter.Format.String())
+		count++
 	}
 
+	fmt.Fprintf(w, "Found %d regional parameters\n", count)
 	return nil
 }
 
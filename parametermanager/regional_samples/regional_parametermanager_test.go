@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"testing"
@@ -158,6 +159,67 @@ func testParameterVersion(t *testing.T, projectID, parameterID, payload string)
 	return parameterVersion, parameterVersionID
 }
 
+// testGetParameter gets the specified regional parameter.
+// It fails the test if the parameter retrieval fails.
+func testGetParameter(t *testing.T, name string) *parametermanagerpb.Parameter {
+	t.Helper()
+	locationId := testLocation(t)
+
+	ctx := context.Background()
+	endpoint := fmt.Sprintf("parametermanager.%s.rep.googleapis.com:443", locationId)
+	client, err := parametermanager.NewClient(ctx, option.WithEndpoint(endpoint))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		t.Fatalf("testGetParameter: failed to get parameter: %v", err)
+	}
+	return parameter
+}
+
+// testGetParameterErr gets the specified regional parameter and returns the error, if any.
+func testGetParameterErr(t *testing.T, name string) error {
+	t.Helper()
+	locationId := testLocation(t)
+
+	ctx := context.Background()
+	endpoint := fmt.Sprintf("parametermanager.%s.rep.googleapis.com:443", locationId)
+	client, err := parametermanager.NewClient(ctx, option.WithEndpoint(endpoint))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	return err
+}
+
+// testGetParameterVersionErr gets the specified regional parameter version and returns the error, if any.
+func testGetParameterVersionErr(t *testing.T, name string) error {
+	t.Helper()
+	locationId := testLocation(t)
+
+	ctx := context.Background()
+	endpoint := fmt.Sprintf("parametermanager.%s.rep.googleapis.com:443", locationId)
+	client, err := parametermanager.NewClient(ctx, option.WithEndpoint(endpoint))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: name,
+	})
+	return err
+}
+
 // testCleanupParameter deletes the specified parameter in the GCP project.
 // It fails the test if the parameter deletion fails.
 func testCleanupParameter(t *testing.T, name string) {
@@ -438,6 +500,10 @@ func TestCreateRegionalParam(t *testing.T) {
 	if got, want := buf.String(), "Created regional parameter:"; !strings.Contains(got, want) {
 		t.Errorf("createParameter: expected %q to contain %q", got, want)
 	}
+
+	if got, want := buf.String(), fmt.Sprintf("projects/%s/locations/%s/parameters/%s", tc.ProjectID, locationId, parameterID); !strings.Contains(got, want) {
+		t.Errorf("createParameter: expected %q to contain %q", got, want)
+	}
 }
 
 // TestCreateStructuredRegionalParam tests the createStructuredRegionalParam function by creating a structured regional parameter,
@@ -490,7 +556,7 @@ func TestCreateRegionalParamVersion(t *testing.T) {
 	parameterVersionID := testName(t)
 	locationId := testLocation(t)
 
-	payload := "test123"
+	payload := []byte("test123")
 	var buf bytes.Buffer
 	if err := createRegionalParamVersion(&buf, tc.ProjectID, locationId, parameterID, parameterVersionID, payload); err != nil {
 		t.Fatal(err)
@@ -501,6 +567,10 @@ func TestCreateRegionalParamVersion(t *testing.T) {
 	if got, want := buf.String(), "Created regional parameter version:"; !strings.Contains(got, want) {
 		t.Errorf("createParameterVersion: expected %q to contain %q", got, want)
 	}
+
+	if got, want := buf.String(), fmt.Sprintf("/locations/%s/", locationId); !strings.Contains(got, want) {
+		t.Errorf("createParameterVersion: expected %q to contain %q", got, want)
+	}
 }
 
 // TestCreateRegionalParamVersionWithSecret tests the createRegionalParamVersionWithSecret function by creating a regional parameter version with a secret reference,
@@ -573,6 +643,45 @@ func TestEnableRegionalParamVersion(t *testing.T) {
 	}
 }
 
+// TestRegionalVersionState tests the disableRegionalParamVersion and enableRegionalParamVersion
+// functions by disabling a regional version and verifying it can no longer be rendered, then
+// enabling it again and verifying the render succeeds.
+func TestRegionalVersionState(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
+	payload := `{"username": "test-user", "host": "localhost"}`
+	parameterVersion, parameterVersionID := testParameterVersion(t, tc.ProjectID, parameterID, payload)
+	locationId := testLocation(t)
+
+	defer testCleanupParameter(t, parameter.Name)
+	defer testCleanupParameterVersion(t, parameterVersion.Name)
+
+	var buf bytes.Buffer
+	if err := disableRegionalParamVersion(&buf, tc.ProjectID, locationId, parameterID, parameterVersionID); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Disabled state: true"; !strings.Contains(got, want) {
+		t.Errorf("DisableParameterVersion: expected %q to contain %q", got, want)
+	}
+
+	if err := renderRegionalParamVersion(&bytes.Buffer{}, tc.ProjectID, locationId, parameterID, parameterVersionID); err == nil {
+		t.Error("RenderParameterVersion: expected an error for a disabled version")
+	}
+
+	buf.Reset()
+	if err := enableRegionalParamVersion(&buf, tc.ProjectID, locationId, parameterID, parameterVersionID); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Disabled state: false"; !strings.Contains(got, want) {
+		t.Errorf("EnableParameterVersion: expected %q to contain %q", got, want)
+	}
+
+	if err := renderRegionalParamVersion(&bytes.Buffer{}, tc.ProjectID, locationId, parameterID, parameterVersionID); err != nil {
+		t.Errorf("RenderParameterVersion: expected an enabled version to render, got %v", err)
+	}
+}
+
 // TestDeleteRegionalParam tests the deleteRegionalParam function by creating a parameter,
 // then attempts to delete the created parameter. It verifies if the parameter
 // was successfully deleted by checking the output.
@@ -591,6 +700,33 @@ func TestDeleteRegionalParam(t *testing.T) {
 	if got, want := buf.String(), "Deleted regional parameter"; !strings.Contains(got, want) {
 		t.Errorf("DeleteParameter: expected %q to contain %q", got, want)
 	}
+
+	if got, want := grpcstatus.Code(testGetParameterErr(t, parameter.Name)), grpccodes.NotFound; got != want {
+		t.Errorf("DeleteParameter: expected GetParameter code %v after delete, got %v", want, got)
+	}
+}
+
+// TestDeleteRegionalParamWithVersions tests the deleteRegionalParam function on a parameter
+// that still has versions and verifies the error explains the versions must be deleted first.
+func TestDeleteRegionalParamWithVersions(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
+	payload := `{"username": "test-user", "host": "localhost"}`
+	parameterVersion, _ := testParameterVersion(t, tc.ProjectID, parameterID, payload)
+	defer testCleanupParameter(t, parameter.Name)
+	defer testCleanupParameterVersion(t, parameterVersion.Name)
+	locationId := testLocation(t)
+
+	var buf bytes.Buffer
+	err := deleteRegionalParam(&buf, tc.ProjectID, locationId, parameterID)
+	if err == nil {
+		t.Fatal("DeleteParameter: expected error for parameter with versions, got nil")
+	}
+
+	if got, want := err.Error(), "delete its versions first"; !strings.Contains(got, want) {
+		t.Errorf("DeleteParameter: expected %q to contain %q", got, want)
+	}
 }
 
 // TestDeleteRegionalParamVersion tests the deleteRegionalParamVersion function by creating a parameter and its version,
@@ -614,6 +750,20 @@ func TestDeleteRegionalParamVersion(t *testing.T) {
 	if got, want := buf.String(), "Deleted regional parameter version"; !strings.Contains(got, want) {
 		t.Errorf("DeleteParameterVersion: expected %q to contain %q", got, want)
 	}
+
+	if got, want := grpcstatus.Code(testGetParameterVersionErr(t, parameterVersion.Name)), grpccodes.NotFound; got != want {
+		t.Errorf("DeleteParameterVersion: expected GetParameterVersion code %v after delete, got %v", want, got)
+	}
+
+	// Deleting the version again reports that there is nothing to delete.
+	buf.Reset()
+	if err := deleteRegionalParamVersion(&buf, tc.ProjectID, locationId, parameterID, parameterVersionID); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), "not found, nothing to delete"; !strings.Contains(got, want) {
+		t.Errorf("DeleteParameterVersion: expected %q to contain %q", got, want)
+	}
 }
 
 // TestGetRegionalParam tests the getRegionalParam function by creating a parameter,
@@ -634,6 +784,29 @@ func TestGetRegionalParam(t *testing.T) {
 	if got, want := buf.String(), fmt.Sprintf("Found regional parameter %s with format JSON", parameter.Name); !strings.Contains(got, want) {
 		t.Errorf("GetParameter: expected %q to contain %q", got, want)
 	}
+
+	if got, want := buf.String(), "Regional parameter is encrypted with a Google-managed key"; !strings.Contains(got, want) {
+		t.Errorf("GetParameter: expected %q to contain %q", got, want)
+	}
+}
+
+// TestGetRegionalParamNotFound tests the getRegionalParam function with a parameter ID that
+// does not exist and verifies the error names the parameter and the location.
+func TestGetRegionalParamNotFound(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	locationId := testLocation(t)
+	parameterID := testName(t)
+
+	var buf bytes.Buffer
+	err := getRegionalParam(&buf, tc.ProjectID, locationId, parameterID)
+	if err == nil {
+		t.Fatal("GetParameter: expected an error for a missing parameter")
+	}
+
+	if got, want := err.Error(), fmt.Sprintf("regional parameter %s not found in location %s", parameterID, locationId); got != want {
+		t.Errorf("GetParameter: expected %q, got %q", want, got)
+	}
 }
 
 // TestGetRegionalParamVersion tests the getRegionalParamVersion function by creating a parameter and its version,
@@ -694,21 +867,44 @@ func TestRenderRegionalParamVersion(t *testing.T) {
 	}
 }
 
-// TestListRegionalParam tests the listRegionalParam function by creating multiple parameters,
-// then attempts to list the created parameters. It verifies if the parameters
-// were successfully listed by checking the output.
-func TestListRegionalParam(t *testing.T) {
+// TestRenderRegionalParamVersionWithoutSecrets tests the renderRegionalParamVersion function with a
+// payload that has no secret references and verifies the rendered payload matches the stored payload.
+func TestRenderRegionalParamVersionWithoutSecrets(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
+	payload := `{"username": "test-user", "host": "localhost"}`
+	parameterVersion, parameterVersionID := testParameterVersion(t, tc.ProjectID, parameterID, payload)
+	locationId := testLocation(t)
+
+	defer testCleanupParameter(t, parameter.Name)
+	defer testCleanupParameterVersion(t, parameterVersion.Name)
+
+	var buf bytes.Buffer
+	if err := renderRegionalParamVersion(&buf, tc.ProjectID, locationId, parameterID, parameterVersionID); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf.String(), fmt.Sprintf("Rendered payload: %s\n", payload); !strings.Contains(got, want) {
+		t.Errorf("RenderParameterVersion: expected %q to contain %q", got, want)
+	}
+}
+
+// TestListRegionalParams tests the listRegionalParams function by creating two parameters
+// in the same location, then verifies both are listed and that every listed name is
+// scoped to that location.
+func TestListRegionalParams(t *testing.T) {
 	tc := testutil.SystemTest(t)
 
+	locationId := testLocation(t)
 	parameter1, _ := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
 	parameter2, _ := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
-	locationId := testLocation(t)
 
 	defer testCleanupParameter(t, parameter1.Name)
 	defer testCleanupParameter(t, parameter2.Name)
 
 	var buf bytes.Buffer
-	if err := listRegionalParam(&buf, tc.ProjectID, locationId); err != nil {
+	if err := listRegionalParams(&buf, tc.ProjectID, locationId); err != nil {
 		t.Fatal(err)
 	}
 
@@ -719,6 +915,13 @@ func TestListRegionalParam(t *testing.T) {
 	if got, want := buf.String(), fmt.Sprintf("Found regional parameter %s with format %s", parameter2.Name, parameter2.Format); !strings.Contains(got, want) {
 		t.Errorf("ListParameter: expected %q to contain %q", got, want)
 	}
+
+	segment := fmt.Sprintf("/locations/%s/", locationId)
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.HasPrefix(line, "Found regional parameter ") && !strings.Contains(line, segment) {
+			t.Errorf("ListParameter: expected %q to contain %q", line, segment)
+		}
+	}
 }
 
 // TestListRegionalParamVersion tests the listRegionalParamVersion function by creating a parameter and its versions,
@@ -775,10 +978,13 @@ func TestCreateRegionalParamWithKmsKey(t *testing.T) {
 	if got, want := buf.String(), fmt.Sprintf("Created regional parameter %s with kms_key %s", parameterName, kms_key); !strings.Contains(got, want) {
 		t.Errorf("createParameter: expected %q to contain %q", got, want)
 	}
+	if got := testGetParameter(t, parameterName).GetKmsKey(); got != kms_key {
+		t.Errorf("createRegionalParamWithKmsKey: expected kms_key %q, got %q", kms_key, got)
+	}
 }
 
-// TestUpdateRegionalParamKmsKey tests the updateRegionalParamKmsKey function by creating a regional parameter with a KMS key,
-// updating the KMS key, and verifying if the parameter was successfully updated by checking the output.
+// TestUpdateRegionalParamKmsKey tests the updateRegionalParamKmsKey function by creating a regional parameter without a KMS key,
+// setting its KMS key, and verifying GetParameter returns the new key.
 func TestUpdateRegionalParamKmsKey(t *testing.T) {
 	tc := testutil.SystemTest(t)
 
@@ -789,7 +995,7 @@ func TestUpdateRegionalParamKmsKey(t *testing.T) {
 	testCreateKeyHSM(t, tc.ProjectID, "go-test-key-ring", keyId)
 	kms_key := fmt.Sprintf("projects/%s/locations/%s/keyRings/go-test-key-ring/cryptoKeys/%s", tc.ProjectID, locationID, keyId)
 
-	parameter, parameterID := testParameterWithKmsKey(t, tc.ProjectID, kms_key)
+	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_UNFORMATTED)
 	defer testCleanupParameter(t, parameter.Name)
 	defer testCleanupKeyVersions(t, fmt.Sprintf("%s/cryptoKeyVersions/1", kms_key))
 
@@ -800,6 +1006,23 @@ func TestUpdateRegionalParamKmsKey(t *testing.T) {
 	if got, want := buf.String(), fmt.Sprintf("Updated regional parameter %s with kms_key %s", parameter.Name, kms_key); !strings.Contains(got, want) {
 		t.Errorf("createParameter: expected %q to contain %q", got, want)
 	}
+	if got := testGetParameter(t, parameter.Name).GetKmsKey(); got != kms_key {
+		t.Errorf("updateRegionalParamKmsKey: expected kms_key %q, got %q", kms_key, got)
+	}
+}
+
+// TestUpdateRegionalParamKmsKeyWrongLocation tests that updateRegionalParamKmsKey rejects a KMS key
+// from another location without calling the API.
+func TestUpdateRegionalParamKmsKeyWrongLocation(t *testing.T) {
+	kmsKey := "projects/project/locations/europe-west1/keyRings/ring/cryptoKeys/key"
+
+	err := updateRegionalParamKmsKey(io.Discard, "project", "us-central1", "parameter", kmsKey)
+	if err == nil {
+		t.Fatal("updateRegionalParamKmsKey: expected an error for a KMS key in another location")
+	}
+	if got, want := err.Error(), "is not in location us-central1"; !strings.Contains(got, want) {
+		t.Errorf("updateRegionalParamKmsKey: expected %q to contain %q", got, want)
+	}
 }
 
 // TestRemoveRegionalParamKmsKey tests the removeRegionalParamKmsKey function by creating a regional parameter with a KMS key,
@@ -826,3 +1049,36 @@ func TestRemoveRegionalParamKmsKey(t *testing.T) {
 		t.Errorf("createParameter: expected %q to contain %q", got, want)
 	}
 }
+
+// TestRenderRegionalParamVersionWithSecrets tests the renderRegionalParamVersionWithSecrets function
+// by referencing a regional secret with a known value and verifies the resolved value is rendered.
+func TestRenderRegionalParamVersionWithSecrets(t *testing.T) {
+	tc := testutil.SystemTest(t)
+
+	parameter, parameterID := testParameter(t, tc.ProjectID, parametermanagerpb.ParameterFormat_JSON)
+	secret := testSecret(t, tc.ProjectID)
+	testSecretVersion(t, secret.Name, []byte("very secret data"))
+	if err := testIamGrantAccess(t, secret.Name, parameter.PolicyMember.IamPolicyUidPrincipal); err != nil {
+		t.Fatal(err)
+	}
+	locationId := testLocation(t)
+	parameterVersionID := testName(t)
+	parameterVersionName := fmt.Sprintf("%s/versions/%s", parameter.Name, parameterVersionID)
+
+	defer testCleanupSecret(t, secret.Name)
+	defer testCleanupParameter(t, parameter.Name)
+	defer testCleanupParameterVersion(t, parameterVersionName)
+
+	var buf bytes.Buffer
+	// Wait for the IAM grant to propagate.
+	time.Sleep(2 * time.Minute)
+	secretVersion := fmt.Sprintf("%s/versions/latest", secret.Name)
+	if err := renderRegionalParamVersionWithSecrets(&buf, tc.ProjectID, locationId, parameterID, parameterVersionID, secretVersion); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPayload := `{"db_host": "localhost","db_password": "very secret data"}`
+	if got, want := buf.String(), fmt.Sprintf("Rendered payload: %s", expectedPayload); !strings.Contains(got, want) {
+		t.Errorf("renderRegionalParamVersionWithSecrets: expected %q to contain %q", got, want)
+	}
+}
@@ -15,6 +15,7 @@
 package regional_parametermanager
 
 // [START parametermanager_disable_regional_param_version]
+// [START parametermanager_enable_regional_param_version]
 import (
 	"context"
 	"fmt"
@@ -26,6 +27,8 @@ import (
 	"google.golang.org/genproto/protobuf/field_mask"
 )
 
+// [END parametermanager_enable_regional_param_version]
+
 // disableRegionalParamVersion disables a regional parameter version using the Parameter Manager SDK for GCP.
 //
 // w: The io.Writer object used to write the output.
@@ -62,12 +65,63 @@ func disableRegionalParamVersion(w io.Writer, projectID, locationID, parameterID
 	}
 
 	// Call the API to disable the parameter version.
-	if _, err := client.UpdateParameterVersion(ctx, req); err != nil {
+	version, err := client.UpdateParameterVersion(ctx, req)
+	if err != nil {
 		return fmt.Errorf("failed to disable parameter version: %w", err)
 	}
 
-	fmt.Fprintf(w, "Disabled regional parameter version %s for parameter %s\n", name, parameterID)
+	fmt.Fprintf(w, "Disabled regional parameter version %s for parameter %s\n", version.Name, parameterID)
+	fmt.Fprintf(w, "Disabled state: %v\n", version.Disabled)
 	return nil
 }
 
 // [END parametermanager_disable_regional_param_version]
+
+// [START parametermanager_enable_regional_param_version]
+// enableRegionalParamVersion enables a regional parameter version using the Parameter Manager SDK for GCP.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// locationID: The ID of the region where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be enabled.
+// versionID: The ID of the version to be enabled.
+//
+// The function returns an error if the parameter version update fails.
+func enableRegionalParamVersion(w io.Writer, projectID, locationID, parameterID, versionID string) error {
+	// Create a new context.
+	ctx := context.Background()
+
+	// Create a Parameter Manager client.
+	endpoint := fmt.Sprintf("parametermanager.%s.rep.googleapis.com:443", locationID)
+	client, err := parametermanager.NewClient(ctx, option.WithEndpoint(endpoint))
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter version to enable.
+	name := fmt.Sprintf("projects/%s/locations/%s/parameters/%s/versions/%s", projectID, locationID, parameterID, versionID)
+
+	// Build the request to enable the parameter version by updating the parameter version.
+	req := &parametermanagerpb.UpdateParameterVersionRequest{
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"disabled"},
+		},
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Name:     name,
+			Disabled: false,
+		},
+	}
+
+	// Call the API to enable the parameter version.
+	version, err := client.UpdateParameterVersion(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to enable parameter version: %w", err)
+	}
+
+	fmt.Fprintf(w, "Enabled regional parameter version: %s for parameter %s\n", version.Name, parameterID)
+	fmt.Fprintf(w, "Disabled state: %v\n", version.Disabled)
+	return nil
+}
+
+// [END parametermanager_enable_regional_param_version]
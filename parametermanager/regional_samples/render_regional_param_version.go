@@ -27,6 +27,10 @@ import (
 
 // renderRegionalParamVersion renders a regional parameter version using the Parameter Manager SDK for GCP.
 //
+// Secret references in a regional parameter must point to regional Secret Manager
+// secrets in the same location, e.g.
+// __REF__(//secretmanager.googleapis.com/projects/my-project/locations/us-central1/secrets/my-secret/versions/latest).
+//
 // w: The io.Writer object used to write the output.
 // projectID: The ID of the project where the parameter is located.
 // locationID: The ID of the region where the parameter is located.
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regional_parametermanager
+
+// [START parametermanager_render_regional_param_with_secrets]
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/option"
+)
+
+// renderRegionalParamVersionWithSecrets creates a regional parameter version that references a
+// regional Secret Manager secret and renders it using the Parameter Manager SDK for GCP.
+//
+// The payload has to name the secret it references, so the secret is passed as
+// secretVersion, a full secret version resource name rather than a secret ID: it selects
+// the version to resolve, such as "latest", and carries the location that is checked. A
+// regional parameter can only reference secrets in its own location, so secretVersion
+// must be a regional secret version in locationID. The parameter's service identity
+// (parameter.PolicyMember) needs roles/secretmanager.secretAccessor on the secret for
+// the render to succeed.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// locationID: The ID of the region where the parameter and the secret are located.
+// parameterID: The ID of the parameter for which the version is to be created.
+// versionID: The ID of the version to be created and rendered.
+// secretVersion: The resource name of the secret version to be referenced.
+// (e.g. "projects/my-project/locations/us-central1/secrets/my-secret/versions/latest")
+//
+// The function returns an error if the secret is in another location or the parameter
+// version creation or rendering fails.
+func renderRegionalParamVersionWithSecrets(w io.Writer, projectID, locationID, parameterID, versionID, secretVersion string) error {
+	// Check the secret's location before calling the API.
+	if !strings.Contains(secretVersion, fmt.Sprintf("/locations/%s/", locationID)) {
+		return fmt.Errorf("secret %s is not in location %s: a regional parameter can only reference secrets in the same location", secretVersion, locationID)
+	}
+
+	// Create a context.
+	ctx := context.Background()
+
+	// Create a Parameter Manager client.
+	endpoint := fmt.Sprintf("parametermanager.%s.rep.googleapis.com:443", locationID)
+	client, err := parametermanager.NewClient(ctx, option.WithEndpoint(endpoint))
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/%s/parameters/%s", projectID, locationID, parameterID)
+
+	payload := []byte(fmt.Sprintf(`{"db_host": "localhost","db_password": "__REF__(//secretmanager.googleapis.com/%s)"}`, secretVersion))
+
+	// Create a parameter version with the secret reference.
+	version, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+	fmt.Fprintf(w, "Created regional parameter version with secret reference: %s\n", version.Name)
+
+	// Render the version to resolve the secret reference.
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: version.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render parameter version: %w", err)
+	}
+
+	// The rendered payload contains the resolved secret value.
+	// Be cautious with logging or displaying this information.
+	fmt.Fprintf(w, "Rendered payload: %s\n", rendered.RenderedPayload)
+	return nil
+}
+
+// [END parametermanager_render_regional_param_with_secrets]
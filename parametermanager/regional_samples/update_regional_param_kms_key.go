@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	parametermanager "cloud.google.com/go/parametermanager/apiv1"
 	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
@@ -28,6 +29,9 @@ import (
 
 // updateRegionalParamKmsKey updates a regional parameter kms_key using the Parameter Manager SDK for GCP.
 //
+// A regional parameter can only be encrypted with a KMS key in the same location as
+// the parameter, so the key's location must match locationID.
+//
 // w: The io.Writer object used to write the output.
 // projectID: The ID of the project where the parameter is located.
 // locationID: The ID of the location where the parameter is located.
@@ -36,8 +40,13 @@ import (
 // (e.g. "projects/my-project/locations/us-central1/keyRings/my-key-ring/cryptoKeys/my-encryption-key")
 // (For more information, see: https://cloud.google.com/secret-manager/parameter-manager/docs/cmek)
 //
-// The function returns an error if the parameter creation fails.
+// The function returns an error if the KMS key is in another location or the parameter update fails.
 func updateRegionalParamKmsKey(w io.Writer, projectID, locationID, parameterID, kmsKey string) error {
+	// Check the key's location before calling the API.
+	if !strings.Contains(kmsKey, fmt.Sprintf("/locations/%s/", locationID)) {
+		return fmt.Errorf("kms_key %s is not in location %s: a regional parameter needs a KMS key in the same location", kmsKey, locationID)
+	}
+
 	// Create a context and a Parameter Manager client.
 	ctx := context.Background()
 
@@ -49,14 +58,13 @@ func updateRegionalParamKmsKey(w io.Writer, projectID, locationID, parameterID,
 	}
 	defer client.Close()
 
-	// Construct the name of the create parameter.
+	// Construct the name of the parameter to update.
 	name := fmt.Sprintf("projects/%s/locations/%s/parameters/%s", projectID, locationID, parameterID)
 
-	// Create a parameter with unformatted format.
+	// Build the request to update only the kms_key field of the parameter.
 	req := &parametermanagerpb.UpdateParameterRequest{
 		Parameter: &parametermanagerpb.Parameter{
 			Name:   name,
-			Format: parametermanagerpb.ParameterFormat_UNFORMATTED,
 			KmsKey: &kmsKey,
 		},
 		UpdateMask: &field_mask.FieldMask{
@@ -25,13 +25,14 @@ import (
 	"google.golang.org/genproto/protobuf/field_mask"
 )
 
-// removeParamKmsKey removes a parameter kms_key using the Parameter Manager SDK for GCP.
+// removeParamKmsKey removes a parameter kms_key using the Parameter Manager SDK for GCP,
+// switching the parameter back to Google-managed encryption.
 //
 // w: The io.Writer object used to write the output.
 // projectID: The ID of the project where the parameter is located.
 // parameterID: The ID of the parameter to be updated.
 //
-// The function returns an error if the parameter creation fails.
+// The function returns an error if the parameter update fails.
 func removeParamKmsKey(w io.Writer, projectID, parameterID string) error {
 	// Create a context and a Parameter Manager client.
 	ctx := context.Background()
@@ -46,11 +47,10 @@ func removeParamKmsKey(w io.Writer, projectID, parameterID string) error {
 	// Construct the name of the create parameter.
 	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
 
-	// Create a parameter with unformatted format.
+	// Clear the kms_key by including it in the update mask without setting a value.
 	req := &parametermanagerpb.UpdateParameterRequest{
 		Parameter: &parametermanagerpb.Parameter{
-			Name:   name,
-			Format: parametermanagerpb.ParameterFormat_UNFORMATTED,
+			Name: name,
 		},
 		UpdateMask: &field_mask.FieldMask{
 			Paths: []string{"kms_key"},
@@ -61,7 +61,7 @@ func removeParamKmsKey(w io.Writer, projectID, parameterID string) error {
 		return fmt.Errorf("failed to update parameter: %w", err)
 	}
 
-	fmt.Fprintf(w, "Removed kms_key for parameter %s\n", parameter.Name)
+	fmt.Fprintf(w, "Removed kms_key from %s\n", parameter.Name)
 	return nil
 }
 
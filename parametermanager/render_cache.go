@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_render_cache]
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// RenderCache caches rendered parameter versions for a fixed TTL so that repeated
+// reads of the same version do not each call RenderParameterVersion.
+//
+// Each cached version has its own lock, so concurrent Gets for a version that is
+// missing or expired wait for a single render instead of all calling the API at
+// once, while Gets for other versions are not blocked. A RenderCache is safe for
+// concurrent use.
+type RenderCache struct {
+	client ParameterClient
+	ttl    time.Duration
+
+	// now returns the current time; tests replace it to control expiry.
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*renderCacheEntry
+}
+
+// renderCacheEntry holds the rendered payload of a single parameter version.
+type renderCacheEntry struct {
+	mu      sync.Mutex
+	payload []byte
+	expires time.Time
+}
+
+// NewRenderCache returns a RenderCache that renders through client and keeps each
+// rendered payload for ttl.
+func NewRenderCache(client ParameterClient, ttl time.Duration) *RenderCache {
+	return &RenderCache{
+		client:  client,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*renderCacheEntry),
+	}
+}
+
+// Get returns the rendered payload of a parameter version, serving it from the cache
+// until its TTL expires. The rendered payload may contain resolved secret values.
+//
+// The function returns an error if the parameter version rendering fails; failed
+// renders are not cached.
+func (c *RenderCache) Get(ctx context.Context, projectID, parameterID, versionID string) ([]byte, error) {
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+
+	// Find or add the entry, holding the cache lock only for the map access.
+	c.mu.Lock()
+	entry, ok := c.entries[name]
+	if !ok {
+		entry = &renderCacheEntry{}
+		c.entries[name] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.payload != nil && c.now().Before(entry.expires) {
+		return bytes.Clone(entry.payload), nil
+	}
+
+	rendered, err := c.client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render parameter version: %w", err)
+	}
+
+	// Keep a non-nil payload so that an empty rendered payload is still cached.
+	entry.payload = append([]byte{}, rendered.RenderedPayload...)
+	entry.expires = c.now().Add(c.ttl)
+	return bytes.Clone(entry.payload), nil
+}
+
+// Invalidate removes a parameter version from the cache, so the next Get renders it again.
+func (c *RenderCache) Invalidate(projectID, parameterID, versionID string) {
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, name)
+}
+
+// [END parametermanager_render_cache]
@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_render_env_file]
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// envNamePattern matches the variable names a POSIX shell accepts.
+var envNamePattern = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// envQuoteReplacer escapes the characters that keep their meaning inside double quotes.
+var envQuoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`")
+
+// renderAsEnvFile renders a JSON object parameter version using the Parameter Manager SDK
+// for GCP and writes it as KEY="value" lines that a shell can source.
+//
+// Each top-level string or number field becomes one line, with the key uppercased and
+// the value double-quoted. Other fields, such as nested objects, and keys that are not
+// valid variable names are skipped; a "#" comment is written for each so the output
+// stays sourceable.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+//
+// The function returns an error if the parameter version rendering fails or the rendered
+// payload is not a JSON object.
+func renderAsEnvFile(w io.Writer, projectID, parameterID, versionID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return renderAsEnvFileWithClient(ctx, client, w, projectID, parameterID, versionID)
+}
+
+// renderAsEnvFileWithClient renders the version through client and writes each
+// top-level field of the rendered JSON object as a KEY="value" line.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+//
+// The function returns an error if the parameter version rendering fails or the rendered
+// payload is not a JSON object.
+func renderAsEnvFileWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, versionID string) error {
+	// Construct the name of the parameter version to render.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+
+	// Call the API to render the parameter version.
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render parameter version: %w", err)
+	}
+
+	// Decode numbers as json.Number so they are written exactly as stored.
+	var fields map[string]any
+	dec := json.NewDecoder(bytes.NewReader(rendered.RenderedPayload))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil || fields == nil {
+		return fmt.Errorf("rendered payload of %s is not a JSON object", name)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		envName := strings.ToUpper(key)
+		if !envNamePattern.MatchString(envName) {
+			fmt.Fprintf(w, "# Skipping %q: not a valid variable name\n", key)
+			continue
+		}
+		switch v := fields[key].(type) {
+		case string:
+			fmt.Fprintf(w, "%s=\"%s\"\n", envName, envQuoteReplacer.Replace(v))
+		case json.Number:
+			fmt.Fprintf(w, "%s=\"%s\"\n", envName, v)
+		case map[string]any:
+			fmt.Fprintf(w, "# Skipping %q: nested objects are not supported\n", key)
+		default:
+			fmt.Fprintf(w, "# Skipping %q: only string and number values are supported\n", key)
+		}
+	}
+	return nil
+}
+
+// [END parametermanager_render_env_file]
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_render_env_subst]
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// envPlaceholderRE matches a ${KEY} placeholder and captures KEY.
+var envPlaceholderRE = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// substituteEnv replaces every ${KEY} placeholder in payload with env[KEY]. Placeholders
+// whose key is not in env are an error, or are left unchanged if allowMissing is set.
+func substituteEnv(payload []byte, env map[string]string, allowMissing bool) ([]byte, error) {
+	missing := make(map[string]bool)
+	out := envPlaceholderRE.ReplaceAllFunc(payload, func(match []byte) []byte {
+		key := string(envPlaceholderRE.FindSubmatch(match)[1])
+		value, ok := env[key]
+		if !ok {
+			missing[key] = true
+			return match
+		}
+		return []byte(value)
+	})
+
+	if len(missing) > 0 && !allowMissing {
+		keys := make([]string, 0, len(missing))
+		for key := range missing {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return nil, fmt.Errorf("payload references undefined variables: %s", strings.Join(keys, ", "))
+	}
+	return out, nil
+}
+
+// renderWithEnvSubst renders a parameter version and then replaces ${KEY} placeholders in
+// the rendered payload with values from env, using the Parameter Manager SDK for GCP.
+// Substitution runs after rendering, so values from env are never sent to the API.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+// env: The values to substitute, keyed by placeholder name.
+// allowMissing: Whether placeholders missing from env are left unchanged instead of
+// causing an error.
+//
+// The function returns an error if the rendering fails or, unless allowMissing is set,
+// the payload references a key that is not in env.
+func renderWithEnvSubst(w io.Writer, projectID, parameterID, versionID string, env map[string]string, allowMissing bool) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter version to render.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+
+	// Call the API to render the parameter version.
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render parameter version: %w", err)
+	}
+
+	payload, err := substituteEnv(rendered.RenderedPayload, env, allowMissing)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "%s\n", payload)
+	return nil
+}
+
+// [END parametermanager_render_env_subst]
@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_render_impersonated]
+import (
+	"context"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
+)
+
+// renderAsServiceAccount renders a parameter version while impersonating a service
+// account, so the API sees targetSA rather than the caller as the identity that rendered
+// the parameter version. The caller needs roles/iam.serviceAccountTokenCreator on
+// targetSA, and targetSA needs the parametermanager.parameterVersions.render permission
+// on the parameter. Secret references are resolved by the parameter's own service
+// identity (parameter.PolicyMember), not by targetSA, so access to the referenced
+// secrets must be granted to that identity.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+// targetSA: The email of the service account to impersonate.
+//
+// The function returns an error if the impersonated credentials cannot be created or the
+// parameter version rendering fails.
+func renderAsServiceAccount(w io.Writer, projectID, parameterID, versionID, targetSA string) error {
+	// targetSA := "name@project.iam.gserviceaccount.com"
+	ctx := context.Background()
+
+	// Mint short-lived tokens for the target service account from the default credentials.
+	ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: targetSA,
+		Scopes:          []string{"https://www.googleapis.com/auth/cloud-platform"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create impersonated credentials: %w", err)
+	}
+
+	// Create a Parameter Manager client that authenticates as the target service account.
+	client, err := parametermanager.NewClient(ctx, option.WithTokenSource(ts))
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter version to render.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+
+	// Call the API to render the parameter version as the target service account.
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render parameter version as %s: %w", targetSA, err)
+	}
+
+	fmt.Fprintf(w, "Rendered parameter version %s as %s\n", rendered.ParameterVersion, targetSA)
+	fmt.Fprintf(w, "Rendered payload: %s\n", rendered.RenderedPayload)
+	return nil
+}
+
+// [END parametermanager_render_impersonated]
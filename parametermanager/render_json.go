@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_render_param_version_json]
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// RenderParamVersionJSON renders a parameter version using the Parameter Manager SDK for GCP
+// and decodes the rendered payload into a value of type T.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+//
+// The function returns an error if the rendering fails or if the rendered payload
+// is not valid JSON for T, in which case the error reports the line and column.
+func RenderParamVersionJSON[T any](ctx context.Context, projectID, parameterID, versionID string) (T, error) {
+	var out T
+
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return out, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter version to render.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+
+	// Call the API to render a parameter version.
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return out, fmt.Errorf("failed to render parameter version: %w", err)
+	}
+
+	// Decode the rendered payload, reporting where decoding failed.
+	payload := rendered.RenderedPayload
+	if err := json.Unmarshal(payload, &out); err != nil {
+		var offset int64 = -1
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			offset = syntaxErr.Offset
+		case errors.As(err, &typeErr):
+			offset = typeErr.Offset
+		}
+		if offset < 0 {
+			return out, fmt.Errorf("failed to decode rendered payload of %s: %w", name, err)
+		}
+		if offset > int64(len(payload)) {
+			offset = int64(len(payload))
+		}
+		before := payload[:offset]
+		line := bytes.Count(before, []byte("\n")) + 1
+		column := len(before) - bytes.LastIndexByte(before, '\n')
+		return out, fmt.Errorf("failed to decode rendered payload of %s at line %d, column %d: %w", name, line, column, err)
+	}
+
+	return out, nil
+}
+
+// [END parametermanager_render_param_version_json]
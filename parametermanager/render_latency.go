@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_render_latency]
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// benchmarkRender renders a parameter version repeatedly using the Parameter Manager SDK
+// for GCP and prints the 50th, 95th and 99th percentile and the mean of the render
+// latency. The first render includes setting up the connection, so a few iterations
+// are needed before the percentiles are representative.
+//
+// ctx: The context used for the API calls; cancelling it stops the benchmark.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+// iterations: The number of renders to time.
+//
+// The function returns an error if iterations is not positive, any render fails, or the
+// context is cancelled between iterations.
+func benchmarkRender(ctx context.Context, w io.Writer, projectID, parameterID, versionID string, iterations int) error {
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return benchmarkRenderWithClient(ctx, client, w, projectID, parameterID, versionID, iterations)
+}
+
+// benchmarkRenderWithClient times iterations renders of the version through client and
+// prints the latency percentiles and mean.
+//
+// ctx: The context used for the API calls; cancelling it stops the benchmark.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+// iterations: The number of renders to time.
+//
+// The function returns an error if iterations is not positive, any render fails, or the
+// context is cancelled between iterations.
+func benchmarkRenderWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, versionID string, iterations int) error {
+	if iterations <= 0 {
+		return fmt.Errorf("iterations must be positive, got %d", iterations)
+	}
+
+	// Construct the name of the parameter version to render.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+
+	durations := make([]time.Duration, 0, iterations)
+	var total time.Duration
+	for i := 0; i < iterations; i++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("stopped after %d renders: %w", i, err)
+		}
+
+		start := time.Now()
+		if _, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+			Name: name,
+		}); err != nil {
+			return fmt.Errorf("failed to render parameter version: %w", err)
+		}
+		elapsed := time.Since(start)
+		durations = append(durations, elapsed)
+		total += elapsed
+	}
+
+	// Use the nearest-rank method: the p-th percentile is the smallest duration that
+	// at least p percent of the renders did not exceed.
+	slices.Sort(durations)
+	percentile := func(p float64) time.Duration {
+		rank := int(math.Ceil(p / 100 * float64(len(durations))))
+		return durations[max(rank, 1)-1]
+	}
+
+	fmt.Fprintf(w, "Rendered %s %d times\n", name, iterations)
+	fmt.Fprintf(w, "p50: %s\n", percentile(50))
+	fmt.Fprintf(w, "p95: %s\n", percentile(95))
+	fmt.Fprintf(w, "p99: %s\n", percentile(99))
+	fmt.Fprintf(w, "mean: %s\n", total/time.Duration(iterations))
+	return nil
+}
+
+// [END parametermanager_render_latency]
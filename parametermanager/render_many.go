@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_render_many]
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// renderMany renders several parameter versions in parallel using the Parameter Manager SDK for GCP.
+//
+// A pool of concurrency workers takes the versions from a shared queue. By default the
+// first failure cancels the renders that have not finished and only that error is
+// returned. With bestEffort set, every version is attempted and the error joins all
+// the failures with errors.Join, alongside the versions that did render.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameters are located.
+// refs: The parameter versions to render.
+// concurrency: The number of renders to run at a time; values below 1 mean 1.
+// bestEffort: Whether to keep rendering after a failure.
+//
+// The function returns the rendered payloads keyed by "parameterID/versionID", and an
+// error if any rendering fails.
+func renderMany(ctx context.Context, projectID string, refs []ParamRef, concurrency int, bestEffort bool) (map[string][]byte, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	// Create a Parameter Manager client shared by all the workers.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	queue := make(chan ParamRef)
+	var (
+		mu       sync.Mutex
+		rendered = make(map[string][]byte, len(refs))
+		errs     []error
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ref := range queue {
+				key := ref.ParameterID + "/" + ref.VersionID
+				name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, ref.ParameterID, ref.VersionID)
+				resp, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+					Name: name,
+				})
+
+				mu.Lock()
+				if err != nil {
+					// In fail-fast mode, keep the first error rather than the
+					// cancellations it causes.
+					if bestEffort || len(errs) == 0 {
+						errs = append(errs, fmt.Errorf("failed to render parameter version %s: %w", key, err))
+					}
+					if !bestEffort {
+						cancel()
+					}
+				} else {
+					rendered[key] = resp.RenderedPayload
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	// Queue the versions, stopping early once the context is cancelled.
+feed:
+	for _, ref := range refs {
+		select {
+		case queue <- ref:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	if len(errs) == 0 {
+		// The caller's context may have been cancelled before any render failed.
+		if err := ctx.Err(); err != nil && len(rendered) < len(refs) {
+			return rendered, err
+		}
+		return rendered, nil
+	}
+	if bestEffort {
+		return rendered, errors.Join(errs...)
+	}
+	return rendered, errs[0]
+}
+
+// [END parametermanager_render_many]
@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_render_param_as_map]
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// renderParamAsMap renders a JSON parameter version using the Parameter Manager SDK for GCP
+// and decodes it into a generic map, for callers without a struct for the payload.
+//
+// Nested objects decode to map[string]interface{}, arrays to []interface{} and
+// numbers to float64, as with encoding/json.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+//
+// The function returns an error if the parameter is not in JSON format, the
+// rendering fails, or the rendered payload is not a JSON object.
+func renderParamAsMap(ctx context.Context, projectID, parameterID, versionID string) (map[string]interface{}, error) {
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Check the parameter's format before rendering.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parameter: %w", err)
+	}
+	if parameter.Format != parametermanagerpb.ParameterFormat_JSON {
+		return nil, fmt.Errorf("parameter %s has format %s, not JSON: use renderParamVersion for the raw payload", name, parameter.Format.String())
+	}
+
+	// Call the API to render the parameter version.
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: fmt.Sprintf("%s/versions/%s", name, versionID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render parameter version: %w", err)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(rendered.RenderedPayload, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode rendered payload as a JSON object: %w", err)
+	}
+	return out, nil
+}
+
+// [END parametermanager_render_param_as_map]
@@ -26,6 +26,10 @@ import (
 
 // renderParamVersion renders a parameter version using the Parameter Manager SDK for GCP.
 //
+// If the payload references Secret Manager secrets, the parameter's service
+// identity (parameter.PolicyMember) must be granted roles/secretmanager.secretAccessor
+// on each referenced secret, otherwise the render fails with PermissionDenied.
+//
 // w: The io.Writer object used to write the output.
 // projectID: The ID of the project where the parameter is located.
 // parameterID: The ID of the parameter for which the version details is to be rendered.
@@ -41,6 +45,21 @@ func renderParamVersion(w io.Writer, projectID, parameterID, versionID string) e
 	}
 	defer client.Close()
 
+	return renderParamVersionWithClient(ctx, client, w, projectID, parameterID, versionID)
+}
+
+// renderParamVersionWithClient renders the version through client and prints the
+// rendered payload.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version details is to be rendered.
+// versionID: The ID of the version to be rendered.
+//
+// The function returns an error if the parameter version rendering fails.
+func renderParamVersionWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, versionID string) error {
 	// Construct the name of the parameter version to get render data.
 	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
 
@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_render_redacted]
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// redactedSecret replaces each resolved secret value in the output of renderRedacted.
+const redactedSecret = "***"
+
+// renderRedacted renders a parameter version using the Parameter Manager SDK for GCP and
+// prints the rendered payload with every resolved secret value replaced by "***", so it
+// can be logged without leaking secrets.
+//
+// The stored payload is fetched as well, and the text around each secret reference in it
+// is matched against the rendered payload to find where the secret values are. The
+// output is built from the stored text and the masks only, so no part of a secret value
+// is printed even if a value happens to contain the surrounding text.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+//
+// The function returns an error if the parameter version retrieval or rendering fails,
+// or if the rendered payload does not line up with the stored one.
+func renderRedacted(w io.Writer, projectID, parameterID, versionID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter version to render.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+
+	// Call the API to get the stored payload, with the references unresolved.
+	version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter version: %w", err)
+	}
+
+	// Call the API to render the parameter version.
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render parameter version: %w", err)
+	}
+
+	redacted, err := redactRendered(version.GetPayload().GetData(), rendered.RenderedPayload)
+	if err != nil {
+		return fmt.Errorf("failed to redact %s: %w", name, err)
+	}
+	fmt.Fprintf(w, "Rendered parameter version %s\n", rendered.ParameterVersion)
+	fmt.Fprintf(w, "Redacted payload: %s\n", redacted)
+	return nil
+}
+
+// redactRendered returns stored with each secret reference replaced by redactedSecret,
+// after checking that the text between the references appears in rendered in order.
+func redactRendered(stored, rendered []byte) ([]byte, error) {
+	// Split the stored payload into the literal text around the references. The quotes
+	// around a reference are optional and are escaped when it sits inside a JSON string.
+	refRE := regexp.MustCompile(`__REF__\(\s*\\?"?//secretmanager\.googleapis\.com/[^"\\)\s]+\\?"?\s*\)`)
+	var literals [][]byte
+	start := 0
+	for _, loc := range refRE.FindAllIndex(stored, -1) {
+		literals = append(literals, stored[start:loc[0]])
+		start = loc[1]
+	}
+	literals = append(literals, stored[start:])
+
+	// The rendered payload is the literals with a secret value between each pair.
+	rest, ok := bytes.CutPrefix(rendered, literals[0])
+	if !ok {
+		return nil, fmt.Errorf("rendered payload does not start with the stored text before the first reference")
+	}
+	var out bytes.Buffer
+	out.Write(literals[0])
+	for i, literal := range literals[1:] {
+		if i == len(literals)-2 {
+			if !bytes.HasSuffix(rest, literal) {
+				return nil, fmt.Errorf("rendered payload does not end with the stored text after the last reference")
+			}
+		} else {
+			end := bytes.Index(rest, literal)
+			if end < 0 {
+				return nil, fmt.Errorf("rendered payload is missing the stored text after reference %d", i+1)
+			}
+			rest = rest[end+len(literal):]
+		}
+		out.WriteString(redactedSecret)
+		out.Write(literal)
+	}
+	return out.Bytes(), nil
+}
+
+// [END parametermanager_render_redacted]
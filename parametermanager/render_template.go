@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_render_template]
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/template"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// renderTemplate renders parameter versions and executes a Go text/template against them
+// using the Parameter Manager SDK for GCP. Each rendered payload is available in the
+// template under its key in params: JSON payloads are decoded so their fields can be
+// addressed, as in {{.db.host}}, and any other payload is exposed as a string.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+// tmpl: The text/template source to execute.
+// params: The parameter versions to render, keyed by the name used in the template.
+//
+// The function returns an error if the template cannot be parsed, a rendering fails,
+// or the template refers to a key that does not exist.
+func renderTemplate(w io.Writer, projectID string, tmpl string, params map[string]ParamRef) error {
+	// Parse the template first so a syntax error is reported without any API calls.
+	// missingkey=error makes a reference to an absent key fail instead of printing "<no value>".
+	t, err := template.New("parameters").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	data := make(map[string]interface{}, len(params))
+	for key, ref := range params {
+		name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, ref.ParameterID, ref.VersionID)
+
+		// Call the API to render the parameter version.
+		rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+			Name: name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to render parameter version %s: %w", name, err)
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(rendered.RenderedPayload, &value); err != nil {
+			value = string(rendered.RenderedPayload)
+		}
+		data[key] = value
+	}
+
+	// Execute into a buffer so nothing is written if the template fails part way.
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	if _, err := buf.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write rendered template: %w", err)
+	}
+	return nil
+}
+
+// [END parametermanager_render_template]
@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_render_param_version_yaml]
+import (
+	"context"
+	"fmt"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"gopkg.in/yaml.v3"
+)
+
+// renderParamVersionYAML renders a parameter version using the Parameter Manager SDK for GCP
+// and decodes the rendered YAML payload into a value of type T.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+//
+// The function returns the decoded value and whether the parameter is in JSON format.
+// YAML is a superset of JSON so decoding a JSON parameter succeeds, but callers may
+// prefer RenderParamVersionJSON for such parameters. The function returns an error if
+// the rendering or decoding fails.
+func renderParamVersionYAML[T any](ctx context.Context, projectID, parameterID, versionID string) (T, bool, error) {
+	var out T
+
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return out, false, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Get the parameter to check its format.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return out, false, fmt.Errorf("failed to get parameter: %w", err)
+	}
+
+	// Call the API to render the parameter version.
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: fmt.Sprintf("%s/versions/%s", name, versionID),
+	})
+	if err != nil {
+		return out, false, fmt.Errorf("failed to render parameter version: %w", err)
+	}
+
+	if err := yaml.Unmarshal(rendered.RenderedPayload, &out); err != nil {
+		return out, false, fmt.Errorf("failed to decode rendered payload as YAML: %w", err)
+	}
+
+	return out, parameter.Format == parametermanagerpb.ParameterFormat_JSON, nil
+}
+
+// [END parametermanager_render_param_version_yaml]
@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_replicate_regions]
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// replicateToRegions copies a global parameter version into each of the given regions
+// using the Parameter Manager SDK for GCP.
+//
+// The stored payload is copied, so secret references are kept unresolved. The regional
+// parameter is created with the same format and labels if it does not exist yet; a KMS
+// key is not copied, because a regional parameter needs a key in its own location. A
+// failure in one region does not stop the others, and a table with the outcome for
+// every region is printed at the end.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+// parameterID: The ID of the global parameter, also used for the regional parameters.
+// versionID: The ID of the version to be replicated.
+// regions: The IDs of the regions to replicate to.
+//
+// The function returns an error if the global parameter version cannot be read, or an
+// error joining the failures of all regions that could not be replicated to.
+func replicateToRegions(w io.Writer, projectID, parameterID, versionID string, regions []string) error {
+	// Create a context and a Parameter Manager client for the global endpoint.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Read the global parameter for its format and labels, and the version for its payload.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	parameter, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter: %w", err)
+	}
+	version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: fmt.Sprintf("%s/versions/%s", name, versionID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter version: %w", err)
+	}
+
+	var errs []error
+	results := make([]string, len(regions))
+	for i, region := range regions {
+		if err := replicateToRegion(ctx, projectID, region, parameterID, versionID, parameter, version.GetPayload().GetData()); err != nil {
+			errs = append(errs, fmt.Errorf("region %s: %w", region, err))
+			results[i] = fmt.Sprintf("FAILED: %v", err)
+			continue
+		}
+		results[i] = "OK"
+	}
+
+	// Align the columns with tabwriter; nothing is written to w until Flush.
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REGION\tSTATUS")
+	for i, region := range regions {
+		fmt.Fprintf(tw, "%s\t%s\n", region, results[i])
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+	fmt.Fprintf(w, "Replicated to %d of %d regions\n", len(regions)-len(errs), len(regions))
+	return errors.Join(errs...)
+}
+
+// replicateToRegion creates the regional parameter if needed and the version with payload,
+// through the regional endpoint of region.
+func replicateToRegion(ctx context.Context, projectID, region, parameterID, versionID string, parameter *parametermanagerpb.Parameter, payload []byte) error {
+	// Create a Parameter Manager client for the regional endpoint.
+	endpoint := fmt.Sprintf("parametermanager.%s.rep.googleapis.com:443", region)
+	client, err := parametermanager.NewClient(ctx, option.WithEndpoint(endpoint))
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Create the regional parameter; one left by an earlier run is reused.
+	parent := fmt.Sprintf("projects/%s/locations/%s", projectID, region)
+	_, err = client.CreateParameter(ctx, &parametermanagerpb.CreateParameterRequest{
+		Parent:      parent,
+		ParameterId: parameterID,
+		Parameter: &parametermanagerpb.Parameter{
+			Format: parameter.Format,
+			Labels: parameter.Labels,
+		},
+	})
+	if err != nil && status.Code(err) != codes.AlreadyExists {
+		return fmt.Errorf("failed to create parameter: %w", err)
+	}
+
+	// Create the regional parameter version with the same payload.
+	_, err = client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             fmt.Sprintf("%s/parameters/%s", parent, parameterID),
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: payload,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+	return nil
+}
+
+// [END parametermanager_replicate_regions]
@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_report_encryption]
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// reportEncryption prints how each parameter in a project is encrypted using the
+// Parameter Manager SDK for GCP: "CMEK:" followed by the key name for parameters with a
+// customer-managed encryption key, or "Google-managed" for all others.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameters are located.
+//
+// The function returns an error if the parameter listing fails.
+func reportEncryption(w io.Writer, projectID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the parent location and build the request to list parameters.
+	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
+	req := &parametermanagerpb.ListParametersRequest{
+		Parent: parent,
+	}
+
+	// Align the columns with tabwriter; nothing is written to w until Flush.
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PARAMETER\tENCRYPTION")
+
+	// Call the API to list parameters and add a row for each one.
+	parameters := client.ListParameters(ctx, req)
+	for {
+		parameter, err := parameters.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameters: %w", err)
+		}
+
+		encryption := "Google-managed"
+		if kmsKey := parameter.GetKmsKey(); kmsKey != "" {
+			encryption = "CMEK:" + kmsKey
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", parameter.Name, encryption)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write encryption report: %w", err)
+	}
+	return nil
+}
+
+// [END parametermanager_report_encryption]
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_report_stale_versions]
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// reportStaleVersions prints the enabled versions of a parameter that are older than
+// maxAge using the Parameter Manager SDK for GCP. Such versions are still in use and
+// may hold values that are due for rotation. Disabled versions are not reported.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose versions are to be checked.
+// maxAge: The age above which an enabled version is reported.
+//
+// The function returns an error if the parameter version listing fails.
+func reportStaleVersions(w io.Writer, projectID, parameterID string, maxAge time.Duration) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return reportStaleVersionsWithClient(ctx, client, w, projectID, parameterID, maxAge)
+}
+
+// reportStaleVersionsWithClient lists the versions through client and prints the enabled
+// ones created more than maxAge ago.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose versions are to be checked.
+// maxAge: The age above which an enabled version is reported.
+//
+// The function returns an error if the parameter version listing fails.
+func reportStaleVersionsWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID string, maxAge time.Duration) error {
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Call the API to list parameter versions and report the old, enabled ones.
+	now := time.Now()
+	stale := 0
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: parent,
+	})
+	for {
+		version, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		if version.Disabled {
+			continue
+		}
+		if age := now.Sub(version.GetCreateTime().AsTime()); age > maxAge {
+			fmt.Fprintf(w, "Parameter version %s is %s old and still enabled; consider rotating it\n", version.Name, age.Round(time.Second))
+			stale++
+		}
+	}
+	fmt.Fprintf(w, "Found %d enabled parameter versions older than %s\n", stale, maxAge)
+	return nil
+}
+
+// [END parametermanager_report_stale_versions]
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_resolve_latest]
+import (
+	"context"
+	"fmt"
+	"path"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// resolveLatest resolves the "latest" alias of a parameter to the ID of its newest enabled
+// version using the Parameter Manager SDK for GCP, so callers can log which concrete
+// version they served.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose latest version is to be resolved.
+//
+// The function returns the short version ID, such as "v1", or an error if listing the
+// versions fails or the parameter has no enabled versions.
+func resolveLatest(ctx context.Context, projectID, parameterID string) (string, error) {
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter whose versions are listed.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Keep the enabled version with the most recent creation time.
+	var latest *parametermanagerpb.ParameterVersion
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: parent,
+	})
+	for {
+		version, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		if version.Disabled {
+			continue
+		}
+		if latest == nil || version.GetCreateTime().AsTime().After(latest.GetCreateTime().AsTime()) {
+			latest = version
+		}
+	}
+
+	if latest == nil {
+		return "", fmt.Errorf("parameter %s has no enabled versions", parent)
+	}
+
+	// The version ID is the last segment of the version resource name.
+	return path.Base(latest.Name), nil
+}
+
+// [END parametermanager_resolve_latest]
@@ -0,0 +1,184 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_restore_project]
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// restoreProject recreates the parameters and versions in an archive written by
+// snapshotProject using the Parameter Manager SDK for GCP.
+//
+// Parameters that already exist are skipped unless overwrite is set. With overwrite,
+// an existing parameter's format, labels and KMS key are replaced by the archived
+// ones, and existing versions with an archived ID are deleted and recreated.
+//
+// Versions are recreated oldest first, so the latest enabled version of a restored
+// parameter is the same one as when the snapshot was taken.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project to restore the parameters into.
+// archivePath: The path of the archive file to read.
+// overwrite: Whether to replace parameters and versions that already exist.
+//
+// The function returns an error if the archive cannot be read or a parameter or
+// version cannot be restored.
+func restoreProject(w io.Writer, projectID, archivePath string, overwrite bool) error {
+	// Read and decode the archive.
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive file: %w", err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer zr.Close()
+
+	// The archive layout written by snapshotProject.
+	var snapshot struct {
+		Parameters map[string]struct {
+			Format   string            `json:"format"`
+			Labels   map[string]string `json:"labels"`
+			KmsKey   string            `json:"kmsKey"`
+			Versions map[string]struct {
+				Payload    []byte    `json:"payload"`
+				Disabled   bool      `json:"disabled"`
+				CreateTime time.Time `json:"createTime"`
+			} `json:"versions"`
+		} `json:"parameters"`
+	}
+	if err := json.NewDecoder(zr).Decode(&snapshot); err != nil {
+		return fmt.Errorf("failed to decode archive: %w", err)
+	}
+
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Restore the parameters in a stable order.
+	parameterIDs := make([]string, 0, len(snapshot.Parameters))
+	for parameterID := range snapshot.Parameters {
+		parameterIDs = append(parameterIDs, parameterID)
+	}
+	sort.Strings(parameterIDs)
+
+	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
+	restoredParams, restoredVersions := 0, 0
+	for _, parameterID := range parameterIDs {
+		entry := snapshot.Parameters[parameterID]
+		format, ok := parametermanagerpb.ParameterFormat_value[entry.Format]
+		if !ok {
+			return fmt.Errorf("invalid parameter format %q for %s in archive", entry.Format, parameterID)
+		}
+
+		parameter := &parametermanagerpb.Parameter{
+			Format: parametermanagerpb.ParameterFormat(format),
+			Labels: entry.Labels,
+		}
+		if entry.KmsKey != "" {
+			parameter.KmsKey = &entry.KmsKey
+		}
+
+		name := fmt.Sprintf("%s/parameters/%s", parent, parameterID)
+		_, err := client.CreateParameter(ctx, &parametermanagerpb.CreateParameterRequest{
+			Parent:      parent,
+			ParameterId: parameterID,
+			Parameter:   parameter,
+		})
+		if status.Code(err) == codes.AlreadyExists {
+			if !overwrite {
+				fmt.Fprintf(w, "Parameter %s already exists, skipping\n", parameterID)
+				continue
+			}
+			parameter.Name = name
+			if _, err := client.UpdateParameter(ctx, &parametermanagerpb.UpdateParameterRequest{
+				Parameter:  parameter,
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"format", "labels", "kms_key"}},
+			}); err != nil {
+				return fmt.Errorf("failed to update parameter %s: %w", parameterID, err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to create parameter %s: %w", parameterID, err)
+		}
+		restoredParams++
+
+		// Recreate the versions in their original creation order, oldest first. Versions
+		// created at the same time are ordered by ID.
+		versionIDs := make([]string, 0, len(entry.Versions))
+		for versionID := range entry.Versions {
+			versionIDs = append(versionIDs, versionID)
+		}
+		sort.Slice(versionIDs, func(i, j int) bool {
+			ti, tj := entry.Versions[versionIDs[i]].CreateTime, entry.Versions[versionIDs[j]].CreateTime
+			if !ti.Equal(tj) {
+				return ti.Before(tj)
+			}
+			return versionIDs[i] < versionIDs[j]
+		})
+
+		for _, versionID := range versionIDs {
+			version := entry.Versions[versionID]
+			req := &parametermanagerpb.CreateParameterVersionRequest{
+				Parent:             name,
+				ParameterVersionId: versionID,
+				ParameterVersion: &parametermanagerpb.ParameterVersion{
+					Disabled: version.Disabled,
+					Payload: &parametermanagerpb.ParameterVersionPayload{
+						Data: version.Payload,
+					},
+				},
+			}
+			_, err := client.CreateParameterVersion(ctx, req)
+			if status.Code(err) == codes.AlreadyExists {
+				// Only reachable with overwrite, since new parameters have no versions.
+				if err := client.DeleteParameterVersion(ctx, &parametermanagerpb.DeleteParameterVersionRequest{
+					Name: fmt.Sprintf("%s/versions/%s", name, versionID),
+				}); err != nil {
+					return fmt.Errorf("failed to replace parameter version %s of %s: %w", versionID, parameterID, err)
+				}
+				_, err = client.CreateParameterVersion(ctx, req)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to create parameter version %s of %s: %w", versionID, parameterID, err)
+			}
+			restoredVersions++
+		}
+	}
+
+	fmt.Fprintf(w, "Restored %d parameters and %d parameter versions into %s\n", restoredParams, restoredVersions, parent)
+	return nil
+}
+
+// [END parametermanager_restore_project]
@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_retry_transient_errors]
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// retryConfig holds the settings used by a single call to withRetry.
+type retryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry; it doubles on each retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts.
+	MaxDelay time.Duration
+}
+
+// defaultRetryConfig returns the retry settings suited to most Parameter Manager calls.
+func defaultRetryConfig() retryConfig {
+	return retryConfig{
+		MaxAttempts: 5,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// withRetry calls op until it succeeds, retrying transient Parameter Manager errors.
+//
+// Errors with code Unavailable, DeadlineExceeded or ResourceExhausted are retried
+// with capped exponential backoff and full jitter, up to cfg.MaxAttempts attempts.
+// Any other error is returned immediately.
+//
+// ctx: The context bounding the retries; cancelling it stops waiting between attempts.
+// cfg: The retry settings for this call, usually from defaultRetryConfig.
+// op: The operation to call, typically a single Parameter Manager request.
+//
+// The function returns the last error from op if it never succeeds, or the context
+// error if ctx is done while waiting to retry.
+func withRetry(ctx context.Context, cfg retryConfig, op func() error) error {
+	delay := cfg.BaseDelay
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil {
+			return nil
+		}
+
+		if !isTransient(err) || attempt >= cfg.MaxAttempts {
+			return err
+		}
+
+		// Sleep for a random duration up to the current delay.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(rand.Int63n(int64(delay) + 1))):
+		}
+
+		delay *= 2
+		if delay > cfg.MaxDelay {
+			delay = cfg.MaxDelay
+		}
+	}
+}
+
+// isTransient reports whether err has a code that is worth retrying.
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
+
+// [END parametermanager_retry_transient_errors]
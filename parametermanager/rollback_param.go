@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_rollback_param]
+import (
+	"context"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rollbackParam rolls a parameter back to an earlier value by copying the stored payload
+// of an old version into a new version using the Parameter Manager SDK for GCP, so the
+// old value becomes the newest version.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be rolled back.
+// targetVersionID: The ID of the version whose payload is to be restored.
+// newVersionID: The ID of the version to be created with the restored payload.
+//
+// The function returns an error if the target version cannot be retrieved or the new
+// version cannot be created, including when newVersionID already exists.
+func rollbackParam(w io.Writer, projectID, parameterID, targetVersionID, newVersionID string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter and of the target version.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	targetName := fmt.Sprintf("%s/versions/%s", parent, targetVersionID)
+
+	// Get the stored payload of the target version.
+	target, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+		Name: targetName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter version: %w", err)
+	}
+
+	// Create the new version with the same payload.
+	version, err := client.CreateParameterVersion(ctx, &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             parent,
+		ParameterVersionId: newVersionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: target.Payload,
+		},
+	})
+	if err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return fmt.Errorf("parameter version %s already exists: %w", newVersionID, err)
+		}
+		return fmt.Errorf("failed to create parameter version: %w", err)
+	}
+
+	fmt.Fprintf(w, "Rolled back parameter to version %s as new version %s\n", target.Name, version.Name)
+	return nil
+}
+
+// [END parametermanager_rollback_param]
@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_rotate_secret]
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rotateReferencedSecret rotates a Secret Manager secret that a parameter version
+// references, disabling the old secret version only once the parameter is known to
+// render with the new value.
+//
+// The new value is added as a secret version, and the parameter version is rendered
+// again to confirm it resolves to that value. This only happens if the parameter
+// references the secret's latest version; if it references a fixed version the check
+// fails and the previous secret version is left enabled, so nothing that renders the
+// parameter breaks.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter and secret are located.
+// parameterID: The ID of the parameter holding the version.
+// versionID: The ID of the parameter version referencing the secret.
+// secretID: The ID of the secret to be rotated.
+// newValue: The new secret value.
+//
+// The function returns an error if adding the secret version fails, the parameter
+// version does not render with the new value, or disabling the previous version fails.
+func rotateReferencedSecret(w io.Writer, projectID, parameterID, versionID, secretID string, newValue []byte) error {
+	// Create a context and the Parameter Manager and Secret Manager clients.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	secretClient, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Secret Manager client: %w", err)
+	}
+	defer secretClient.Close()
+
+	// Find the secret version that is current before the rotation, if there is one.
+	secretName := fmt.Sprintf("projects/%s/secrets/%s", projectID, secretID)
+	var previous string
+	latest, err := secretClient.GetSecretVersion(ctx, &secretmanagerpb.GetSecretVersionRequest{
+		Name: secretName + "/versions/latest",
+	})
+	switch {
+	case err == nil:
+		previous = latest.Name
+	case status.Code(err) != codes.NotFound:
+		return fmt.Errorf("failed to get latest secret version: %w", err)
+	}
+
+	// Call the API to add the new value as a secret version.
+	added, err := secretClient.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent: secretName,
+		Payload: &secretmanagerpb.SecretPayload{
+			Data: newValue,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add secret version: %w", err)
+	}
+	fmt.Fprintf(w, "Added secret version %s\n", added.Name)
+
+	// Render the parameter version and confirm it picked up the new value.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render parameter version: %w", err)
+	}
+	if !bytes.Contains(rendered.RenderedPayload, newValue) {
+		return fmt.Errorf("parameter version %s does not render with the new value of %s, so no secret version was disabled", name, secretName)
+	}
+	fmt.Fprintf(w, "Confirmed parameter version %s renders with the new secret value\n", name)
+
+	if previous == "" {
+		fmt.Fprintf(w, "No previous secret version to disable\n")
+		return nil
+	}
+
+	// Call the API to disable the previous secret version.
+	if _, err := secretClient.DisableSecretVersion(ctx, &secretmanagerpb.DisableSecretVersionRequest{
+		Name: previous,
+	}); err != nil {
+		return fmt.Errorf("failed to disable secret version %s: %w", previous, err)
+	}
+	fmt.Fprintf(w, "Disabled previous secret version %s\n", previous)
+	return nil
+}
+
+// [END parametermanager_rotate_secret]
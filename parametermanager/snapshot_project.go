@@ -0,0 +1,158 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_snapshot_project]
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// projectSnapshot is the document written by snapshotProject and read by restoreProject.
+type projectSnapshot struct {
+	// Parameters maps each parameter ID to its metadata and versions.
+	Parameters map[string]parameterSnapshot `json:"parameters"`
+}
+
+// parameterSnapshot is a single parameter in a projectSnapshot.
+type parameterSnapshot struct {
+	// Format is the format of the parameter, e.g. "JSON".
+	Format string `json:"format"`
+	// Labels are the labels of the parameter.
+	Labels map[string]string `json:"labels,omitempty"`
+	// KmsKey is the customer-managed encryption key of the parameter, if any.
+	KmsKey string `json:"kmsKey,omitempty"`
+	// Versions maps each version ID to its stored payload and state.
+	Versions map[string]versionSnapshot `json:"versions"`
+}
+
+// versionSnapshot is a single parameter version in a parameterSnapshot.
+type versionSnapshot struct {
+	// Payload is the stored (unrendered) payload, encoded as base64 in JSON.
+	Payload []byte `json:"payload"`
+	// Disabled reports whether the version was disabled.
+	Disabled bool `json:"disabled"`
+	// CreateTime is when the version was created, so that a restore can recreate the
+	// versions in their original order.
+	CreateTime time.Time `json:"createTime"`
+}
+
+// snapshotProject writes every parameter in a project, with its metadata and all its
+// versions, to a single gzip-compressed JSON archive using the Parameter Manager SDK for GCP.
+//
+// The stored payloads are archived, so secret references are kept as references and
+// no secret values are written. The archive is only readable by its owner.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project to be archived.
+// archivePath: The path of the archive file to write.
+//
+// The function returns an error if reading the parameters or their versions, or
+// writing the archive, fails.
+func snapshotProject(w io.Writer, projectID, archivePath string) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	snapshot := projectSnapshot{Parameters: make(map[string]parameterSnapshot)}
+	versionCount := 0
+
+	// List every parameter in the project.
+	parameters := client.ListParameters(ctx, &parametermanagerpb.ListParametersRequest{
+		Parent: fmt.Sprintf("projects/%s/locations/global", projectID),
+	})
+	for {
+		parameter, err := parameters.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list parameters: %w", err)
+		}
+
+		entry := parameterSnapshot{
+			Format:   parameter.Format.String(),
+			Labels:   parameter.Labels,
+			KmsKey:   parameter.GetKmsKey(),
+			Versions: make(map[string]versionSnapshot),
+		}
+
+		// List the versions and fetch each one's stored payload.
+		versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+			Parent: parameter.Name,
+		})
+		for {
+			listed, err := versions.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to list versions of %s: %w", parameter.Name, err)
+			}
+
+			version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+				Name: listed.Name,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get parameter version: %w", err)
+			}
+			entry.Versions[path.Base(version.Name)] = versionSnapshot{
+				Payload:    version.GetPayload().GetData(),
+				Disabled:   version.Disabled,
+				CreateTime: version.GetCreateTime().AsTime(),
+			}
+			versionCount++
+		}
+
+		snapshot.Parameters[path.Base(parameter.Name)] = entry
+	}
+
+	// Write the snapshot as gzip-compressed JSON.
+	f, err := os.OpenFile(archivePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create archive file: %w", err)
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	if err := json.NewEncoder(zw).Encode(snapshot); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	fmt.Fprintf(w, "Archived %d parameters and %d parameter versions to %s\n", len(snapshot.Parameters), versionCount, archivePath)
+	return nil
+}
+
+// [END parametermanager_snapshot_project]
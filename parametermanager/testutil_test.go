@@ -0,0 +1,234 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/GoogleCloudPlatform/golang-samples/internal/testutil"
+	"google.golang.org/api/iterator"
+	grpccodes "google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// The setupTest helpers create a resource for a system test and register its deletion with
+// t.Cleanup, so a test needs no defer statements of its own. Each also returns the cleanup
+// function for tests that need the resource gone before they finish; it runs at most once.
+// The WithClient variants take the client and project from testProjectClient, so they also
+// work against the in-memory server. Failed deletions are reported as test errors.
+
+// setupTestProject returns the project used by system tests, read from the
+// GOLANG_SAMPLES_PROJECT_ID environment variable, or skips the test if it is not set.
+func setupTestProject(t *testing.T) string {
+	t.Helper()
+	return testutil.SystemTest(t).ProjectID
+}
+
+// testResourceID returns a resource ID made of the current UTC time and a random suffix,
+// so IDs from concurrent test runs do not collide and leaked resources show their age.
+func testResourceID(t *testing.T) string {
+	t.Helper()
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		t.Fatalf("testResourceID: failed to generate suffix: %v", err)
+	}
+	return fmt.Sprintf("test-%s-%s", time.Now().UTC().Format("20060102-150405"), hex.EncodeToString(suffix))
+}
+
+// setupTestParameter creates a global parameter with the given format in the test project.
+// Its cleanup deletes any versions left on the parameter and then the parameter itself.
+func setupTestParameter(t *testing.T, format parametermanagerpb.ParameterFormat) (projectID, parameterID string, cleanup func()) {
+	t.Helper()
+
+	projectID = setupTestProject(t)
+	parameterID, cleanup = setupTestParameterWithClient(t, setupTestClient(t), projectID, format)
+	return projectID, parameterID, cleanup
+}
+
+// setupTestParameterWithClient is like setupTestParameter, but creates the parameter in
+// projectID through client, so it also works with the fake client from testProjectClient.
+func setupTestParameterWithClient(t *testing.T, client ParameterClient, projectID string, format parametermanagerpb.ParameterFormat) (parameterID string, cleanup func()) {
+	t.Helper()
+
+	parameterID = testResourceID(t)
+	parameter, err := client.CreateParameter(context.Background(), &parametermanagerpb.CreateParameterRequest{
+		Parent:      fmt.Sprintf("projects/%s/locations/global", projectID),
+		ParameterId: parameterID,
+		Parameter: &parametermanagerpb.Parameter{
+			Format: format,
+		},
+	})
+	if err != nil {
+		t.Fatalf("setupTestParameter: failed to create parameter: %v", err)
+	}
+
+	cleanup = onceCleanup(t, func() { testDeleteParameterTreeWithClient(t, client, parameter.Name) })
+	return parameterID, cleanup
+}
+
+// setupTestParameterVersion creates a version holding payload for a global parameter.
+func setupTestParameterVersion(t *testing.T, projectID, parameterID, payload string) (versionID string, cleanup func()) {
+	t.Helper()
+
+	versionID = testResourceID(t)
+	_, cleanup = setupTestParameterVersionWithClient(t, setupTestClient(t), projectID, parameterID, versionID, payload)
+	return versionID, cleanup
+}
+
+// setupTestParameterVersionWithClient is like setupTestParameterVersion, but creates the
+// version with the given ID through client and returns its resource name.
+func setupTestParameterVersionWithClient(t *testing.T, client ParameterClient, projectID, parameterID, versionID, payload string) (name string, cleanup func()) {
+	t.Helper()
+
+	version, err := client.CreateParameterVersion(context.Background(), &parametermanagerpb.CreateParameterVersionRequest{
+		Parent:             fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID),
+		ParameterVersionId: versionID,
+		ParameterVersion: &parametermanagerpb.ParameterVersion{
+			Payload: &parametermanagerpb.ParameterVersionPayload{
+				Data: []byte(payload),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("setupTestParameterVersion: failed to create parameter version: %v", err)
+	}
+
+	cleanup = onceCleanup(t, func() {
+		err := client.DeleteParameterVersion(context.Background(), &parametermanagerpb.DeleteParameterVersionRequest{Name: version.Name})
+		if err != nil && grpcstatus.Code(err) != grpccodes.NotFound {
+			t.Errorf("setupTestParameterVersion: failed to delete parameter version: %v", err)
+		}
+	})
+	return version.Name, cleanup
+}
+
+// setupTestClient creates a Parameter Manager client for the API and closes it once the
+// test and all of its cleanups have finished.
+func setupTestClient(t *testing.T) *parametermanager.Client {
+	t.Helper()
+
+	client, err := parametermanager.NewClient(context.Background())
+	if err != nil {
+		t.Fatalf("setupTestClient: failed to create client: %v", err)
+	}
+	// Registered before any cleanup that uses the client, so it runs after them.
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// setupTestSecret creates an automatically replicated secret in the test project.
+func setupTestSecret(t *testing.T) (projectID, secretID string, cleanup func()) {
+	t.Helper()
+
+	projectID = setupTestProject(t)
+	secretID = testResourceID(t)
+
+	ctx := context.Background()
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("setupTestSecret: failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	secret, err := client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+		Parent:   fmt.Sprintf("projects/%s", projectID),
+		SecretId: secretID,
+		Secret: &secretmanagerpb.Secret{
+			Replication: &secretmanagerpb.Replication{
+				Replication: &secretmanagerpb.Replication_Automatic_{
+					Automatic: &secretmanagerpb.Replication_Automatic{},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("setupTestSecret: failed to create secret: %v", err)
+	}
+
+	cleanup = onceCleanup(t, func() { testCleanupSecret(t, secret.Name) })
+	return projectID, secretID, cleanup
+}
+
+// onceCleanup registers f with t.Cleanup and returns a function that runs it early.
+// However it is reached, f runs at most once.
+func onceCleanup(t *testing.T, f func()) func() {
+	var once sync.Once
+	cleanup := func() { once.Do(f) }
+	t.Cleanup(cleanup)
+	return cleanup
+}
+
+// testDeleteParameterTree deletes the versions of the named global parameter and then the
+// parameter itself. A parameter that does not exist is not an error.
+func testDeleteParameterTree(t *testing.T, name string) {
+	t.Helper()
+
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("testDeleteParameterTree: failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	testDeleteParameterTreeWithClient(t, client, name)
+}
+
+// testDeleteParameterTreeWithClient is like testDeleteParameterTree, but deletes through
+// client. Failed deletions are reported as test errors.
+func testDeleteParameterTreeWithClient(t *testing.T, client ParameterClient, name string) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	// Collect the version names before deleting, so the listing is not disturbed.
+	var versionNames []string
+	it := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{Parent: name})
+	for {
+		version, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if grpcstatus.Code(err) == grpccodes.NotFound {
+			return
+		}
+		if err != nil {
+			t.Errorf("testDeleteParameterTree: failed to list parameter versions: %v", err)
+			return
+		}
+		versionNames = append(versionNames, version.Name)
+	}
+
+	for _, versionName := range versionNames {
+		err := client.DeleteParameterVersion(ctx, &parametermanagerpb.DeleteParameterVersionRequest{Name: versionName})
+		if err != nil && grpcstatus.Code(err) != grpccodes.NotFound {
+			t.Errorf("testDeleteParameterTree: failed to delete parameter version: %v", err)
+		}
+	}
+	err := client.DeleteParameter(ctx, &parametermanagerpb.DeleteParameterRequest{Name: name})
+	if err != nil && grpcstatus.Code(err) != grpccodes.NotFound {
+		t.Errorf("testDeleteParameterTree: failed to delete parameter: %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_traced_render_param_version]
+import (
+	"context"
+	"fmt"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/status"
+)
+
+// tracedRender renders a parameter version using the Parameter Manager SDK for GCP inside
+// an OpenTelemetry span named "parametermanager.RenderParameterVersion".
+//
+// The span carries the parameter name in the "parametermanager.parameter" attribute.
+// On failure the span status is set to Error and the gRPC code is recorded in the
+// "rpc.grpc.status_code" attribute.
+//
+// ctx: The context used for the API calls and the parent of the span.
+// tracer: The tracer used to start the span.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+//
+// The function returns the rendered payload, or an error if the rendering fails.
+func tracedRender(ctx context.Context, tracer trace.Tracer, projectID, parameterID, versionID string) ([]byte, error) {
+	// Construct the names of the parameter and of the version to render.
+	parameter := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+	name := fmt.Sprintf("%s/versions/%s", parameter, versionID)
+
+	ctx, span := tracer.Start(ctx, "parametermanager.RenderParameterVersion",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("parametermanager.parameter", parameter)))
+	defer span.End()
+
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, "failed to create Parameter Manager client")
+		return nil, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Call the API to render the parameter version.
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: name,
+	})
+	if err != nil {
+		code := status.Code(err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("rpc.grpc.status_code", code.String()))
+		span.SetStatus(otelcodes.Error, code.String())
+		return nil, fmt.Errorf("failed to render parameter version: %w", err)
+	}
+
+	return rendered.RenderedPayload, nil
+}
+
+// [END parametermanager_traced_render_param_version]
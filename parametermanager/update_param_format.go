@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_update_param_format]
+import (
+	"context"
+	"fmt"
+	"io"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// updateParamFormat updates the format of a parameter using the Parameter Manager SDK for GCP.
+//
+// Changing the format does not rewrite existing versions: their stored payloads are
+// left as they are and only versions created afterwards are validated against the
+// new format. If the service rejects the change because existing versions do not
+// conform, the returned error says so.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be updated.
+// format: The new format type of the parameter (UNFORMATTED, YAML, JSON).
+//
+// The function returns an error if the parameter retrieval or update fails.
+func updateParamFormat(w io.Writer, projectID, parameterID string, format parametermanagerpb.ParameterFormat) error {
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter to update.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Get the parameter to report its current format.
+	current, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+		Name: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parameter: %w", err)
+	}
+
+	// Build the request to update only the format of the parameter.
+	req := &parametermanagerpb.UpdateParameterRequest{
+		Parameter: &parametermanagerpb.Parameter{
+			Name:   name,
+			Format: format,
+		},
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"format"},
+		},
+	}
+
+	// Call the API to update the parameter.
+	parameter, err := client.UpdateParameter(ctx, req)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.FailedPrecondition:
+			return fmt.Errorf("failed to update parameter format to %s, existing versions may not conform to the new format: %w", format.String(), err)
+		}
+		return fmt.Errorf("failed to update parameter: %w", err)
+	}
+
+	fmt.Fprintf(w, "Updated parameter %s format from %s to %s\n", parameter.Name, current.Format.String(), parameter.Format.String())
+	return nil
+}
+
+// [END parametermanager_update_param_format]
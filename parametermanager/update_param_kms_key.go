@@ -46,6 +46,21 @@ func updateParamKmsKey(w io.Writer, projectID, parameterID, kmsKey string) error
 	}
 	defer client.Close()
 
+	return updateParamKmsKeyWithClient(ctx, client, w, projectID, parameterID, kmsKey)
+}
+
+// updateParamKmsKeyWithClient updates only the kms_key field of the parameter through
+// client, using an update mask.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be updated.
+// kmsKey: The ID of the KMS key to be used for encryption.
+//
+// The function returns an error if the parameter update fails.
+func updateParamKmsKeyWithClient(ctx context.Context, client ParameterClient, w io.Writer, projectID, parameterID, kmsKey string) error {
 	// Construct the name of the create parameter.
 	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
 
@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_update_param_labels]
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+// updateParamLabels updates the labels of a parameter using the Parameter Manager SDK for GCP.
+//
+// The "labels" update mask replaces the whole label map. When merge is true the
+// existing labels are read first and the given labels are added to them, with
+// the given values winning on conflicting keys.
+//
+// w: The io.Writer object used to write the output.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter to be updated.
+// labels: The labels to set on the parameter.
+// merge: Whether to keep the existing labels that are not in labels.
+//
+// The function returns an error if a label is invalid or the parameter retrieval or update fails.
+func updateParamLabels(w io.Writer, projectID, parameterID string, labels map[string]string, merge bool) error {
+	// Validate the labels before calling the API, against the constraints GCP enforces
+	// on resource labels.
+	labelKeyRE := regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	labelValueRE := regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+	for key, value := range labels {
+		if !labelKeyRE.MatchString(key) {
+			return fmt.Errorf("invalid label key %q: must start with a lowercase letter and contain at most 63 lowercase letters, digits, underscores, or dashes", key)
+		}
+		if !labelValueRE.MatchString(value) {
+			return fmt.Errorf("invalid value %q for label %q: must contain at most 63 lowercase letters, digits, underscores, or dashes", value, key)
+		}
+	}
+
+	// Create a context and a Parameter Manager client.
+	ctx := context.Background()
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter to update.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// In merge mode, start from the labels already on the parameter.
+	newLabels := make(map[string]string)
+	if merge {
+		current, err := client.GetParameter(ctx, &parametermanagerpb.GetParameterRequest{
+			Name: name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get parameter: %w", err)
+		}
+		for key, value := range current.Labels {
+			newLabels[key] = value
+		}
+	}
+	for key, value := range labels {
+		newLabels[key] = value
+	}
+
+	// Build the request to update the labels of the parameter.
+	req := &parametermanagerpb.UpdateParameterRequest{
+		Parameter: &parametermanagerpb.Parameter{
+			Name:   name,
+			Labels: newLabels,
+		},
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"labels"},
+		},
+	}
+
+	// Call the API to update the parameter.
+	parameter, err := client.UpdateParameter(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to update parameter: %w", err)
+	}
+
+	fmt.Fprintf(w, "Updated labels of parameter %s\n", parameter.Name)
+	keys := make([]string, 0, len(parameter.Labels))
+	for key := range parameter.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(w, "Label %s=%s\n", key, parameter.Labels[key])
+	}
+	return nil
+}
+
+// [END parametermanager_update_param_labels]
@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_version_history]
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// VersionInfo summarizes a parameter version for versionHistory.
+type VersionInfo struct {
+	// VersionID is the ID of the parameter version.
+	VersionID string
+	// CreateTime is when the version was created.
+	CreateTime time.Time
+	// Disabled reports whether the version is disabled.
+	Disabled bool
+	// SHA256 is the checksum of the stored payload; it is the zero value for a version
+	// whose payload is not returned.
+	SHA256 [sha256.Size]byte
+}
+
+// versionHistory returns every version of a parameter, oldest first, with a checksum of
+// each stored payload, using the Parameter Manager SDK for GCP. Versions with equal
+// checksums hold the same payload, which shows where a value was uploaded again.
+//
+// Listing does not return payloads, so each version is fetched as well.
+//
+// ctx: The context used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose history is to be returned.
+//
+// The function returns an error if listing or fetching the versions fails.
+func versionHistory(ctx context.Context, projectID, parameterID string) ([]VersionInfo, error) {
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	return versionHistoryWithClient(ctx, client, projectID, parameterID)
+}
+
+// versionHistoryWithClient lists the versions through client and gets each one to
+// compute the checksum of its stored payload.
+//
+// ctx: The context used for the API calls.
+// client: The Parameter Manager client used for the API calls.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter whose history is to be returned.
+//
+// The function returns an error if listing or fetching the versions fails.
+func versionHistoryWithClient(ctx context.Context, client ParameterClient, projectID, parameterID string) ([]VersionInfo, error) {
+	// Construct the name of the parent parameter.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	var history []VersionInfo
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: parent,
+	})
+	for {
+		listed, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+
+		// Fetch the version for its payload.
+		version, err := client.GetParameterVersion(ctx, &parametermanagerpb.GetParameterVersionRequest{
+			Name: listed.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parameter version %s: %w", listed.Name, err)
+		}
+
+		// The version ID is the last segment of the version's resource name.
+		info := VersionInfo{
+			VersionID:  path.Base(version.Name),
+			CreateTime: version.GetCreateTime().AsTime(),
+			Disabled:   version.Disabled,
+		}
+		if version.Payload != nil {
+			info.SHA256 = sha256.Sum256(version.Payload.Data)
+		}
+		history = append(history, info)
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].CreateTime.Before(history[j].CreateTime)
+	})
+	return history, nil
+}
+
+// [END parametermanager_version_history]
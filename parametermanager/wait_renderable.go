@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_wait_for_renderable]
+import (
+	"context"
+	"fmt"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+)
+
+// waitForRenderable polls a parameter version until it can be rendered using the
+// Parameter Manager SDK for GCP.
+//
+// A version that references a freshly created secret may fail to render until the
+// secret and its IAM bindings have propagated. The render is retried with
+// exponential backoff until it succeeds, the timeout elapses, or ctx is done.
+//
+// ctx: The context used for the API calls; cancelling it stops the polling.
+// projectID: The ID of the project where the parameter is located.
+// parameterID: The ID of the parameter for which the version is to be rendered.
+// versionID: The ID of the version to be rendered.
+// timeout: The maximum time to wait for the version to become renderable.
+//
+// The function returns the last render error if the timeout elapses, or the
+// context error if ctx is done before the first attempt.
+func waitForRenderable(ctx context.Context, projectID, parameterID, versionID string, timeout time.Duration) error {
+	// Return immediately if the context is already done.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Create a Parameter Manager client.
+	client, err := parametermanager.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Parameter Manager client: %w", err)
+	}
+	defer client.Close()
+
+	// Construct the name of the parameter version to render.
+	name := fmt.Sprintf("projects/%s/locations/global/parameters/%s/versions/%s", projectID, parameterID, versionID)
+	req := &parametermanagerpb.RenderParameterVersionRequest{
+		Name: name,
+	}
+
+	const maxBackoff = 5 * time.Second
+	backoff := 100 * time.Millisecond
+	for {
+		_, err := client.RenderParameterVersion(ctx, req)
+		if err == nil {
+			return nil
+		}
+
+		// Wait before the next attempt, giving up once the deadline passes.
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("parameter version %s not renderable after %s: %w", name, timeout, err)
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// [END parametermanager_wait_for_renderable]
@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parametermanager
+
+// [START parametermanager_watch_latest]
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	parametermanager "cloud.google.com/go/parametermanager/apiv1"
+	parametermanagerpb "cloud.google.com/go/parametermanager/apiv1/parametermanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// WatchLatest polls a parameter for its latest enabled version and sends the rendered
+// payload each time it changes, so long-running services can reload configuration
+// without restarting.
+//
+// The first poll happens immediately and always sends the current payload; later polls
+// run every interval and send only when the SHA-256 checksum of the rendered payload
+// differs from the last one sent. Both channels are closed once ctx is done.
+//
+// Polling errors do not stop the watcher. The error channel holds at most one pending
+// error, and further errors are dropped until it is read. An interval that is not
+// positive is reported on the error channel, and both channels are closed at once.
+func WatchLatest(ctx context.Context, projectID, parameterID string, interval time.Duration) (<-chan []byte, <-chan error) {
+	values := make(chan []byte)
+	errs := make(chan error, 1)
+
+	// time.NewTicker panics on an interval that is not positive.
+	if interval <= 0 {
+		errs <- fmt.Errorf("invalid polling interval %v: must be positive", interval)
+		close(values)
+		close(errs)
+		return values, errs
+	}
+
+	go func() {
+		defer close(values)
+		defer close(errs)
+
+		sendErr := func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		}
+
+		// Create a Parameter Manager client, used for every render.
+		client, err := parametermanager.NewClient(ctx)
+		if err != nil {
+			sendErr(fmt.Errorf("failed to create Parameter Manager client: %w", err))
+			return
+		}
+		defer client.Close()
+
+		var last [sha256.Size]byte
+		sent := false
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			payload, err := renderLatest(ctx, client, projectID, parameterID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				sendErr(err)
+			} else if sum := sha256.Sum256(payload); !sent || sum != last {
+				select {
+				case values <- payload:
+					last, sent = sum, true
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return values, errs
+}
+
+// renderLatest renders the latest enabled version of a parameter, using client for both
+// the version lookup and the render.
+func renderLatest(ctx context.Context, client *parametermanager.Client, projectID, parameterID string) ([]byte, error) {
+	// Construct the name of the parameter whose versions are listed.
+	parent := fmt.Sprintf("projects/%s/locations/global/parameters/%s", projectID, parameterID)
+
+	// Keep the enabled version with the most recent creation time.
+	var latest *parametermanagerpb.ParameterVersion
+	versions := client.ListParameterVersions(ctx, &parametermanagerpb.ListParameterVersionsRequest{
+		Parent: parent,
+	})
+	for {
+		version, err := versions.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list parameter versions: %w", err)
+		}
+		if version.Disabled {
+			continue
+		}
+		if latest == nil || version.GetCreateTime().AsTime().After(latest.GetCreateTime().AsTime()) {
+			latest = version
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("parameter %s has no enabled versions", parent)
+	}
+
+	rendered, err := client.RenderParameterVersion(ctx, &parametermanagerpb.RenderParameterVersionRequest{
+		Name: latest.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render parameter version: %w", err)
+	}
+	return rendered.RenderedPayload, nil
+}
+
+// [END parametermanager_watch_latest]